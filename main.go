@@ -2,52 +2,65 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/bhavya5jain/go-django-unchained/src"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "perft" {
+		if err := perft(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// engine speaks uci over stdin & stdout by default
+	if err := runUCI(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
 
-	// src.UpdatePositionTest_Promotion()
-
-	// fenPosition1 := src.Fen("8/8/8/8/8/8/PPP2PPP/R6R w KQkq - 0 1")
-	// randomPosition := src.Fen("2r5/2r2ppp/5k2/B1nBb3/2R5/6P1/P4P1P/3R2K1 b - - 2 35")
-	// // firstMove := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
-
-	// random := src.FenParser(randomPosition)
-
-	// random.PrintChessPosition()
-
-	// moveList := src.GenerateMoves(random, 1)
-
-	// fmt.Println(moveList)
-	// // src.FenParser(randomPosition).PrintChessPosition()
-	// src.FenParser(fenPosition1).PrintChessPosition()
-	// src.FenParser(firstMove).PrintChessPosition()
-
-	// src.PrintAttackingSquares(0x2040810204080)
-
-	// src.GenerateSquareMasks()
-
-	// fmt.Println("Piecetype: ", src.Pawn)
-
-	// position, err := randomPosition.Parse()
-	// if err != nil {
-	// 	fmt.Println(err)
-	// 	return
-	// }
-
-	// fmt.Println(position)
-
-	// for k, v := range position {
-	// 	fmt.Printf("%v: \n%v\n", k, v)
-	// }
-
-	// var move src.Move = 3<<14 + 3
-
-	// // moveType, _ := src.UpdatePosition(move)
-
-	// fmt.Println(moveType)
-
-	fmt.Println(src.Bitboard(0x1000000000))
+// perft <fen> <depth>
+// prints node count of every root move followed by the total
+func perft(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: perft <fen> <depth>")
+	}
+
+	// fen may be passed as a single quoted argument or as its 6 components
+	fen := src.Fen(strings.Join(args[:len(args)-1], " "))
+	depth, err := strconv.Atoi(args[len(args)-1])
+	if err != nil {
+		return fmt.Errorf("depth %s: %w", args[len(args)-1], err)
+	}
+	if depth < 1 {
+		return fmt.Errorf("usage: perft <fen> <depth>, depth %d is less than 1", depth)
+	}
+
+	position, err := fen.Parse()
+	if err != nil {
+		return err
+	}
+
+	divide := src.Divide(position, depth)
+	moves := make([]string, 0, len(divide))
+	nodes := uint64(0)
+	for move, n := range divide {
+		moves = append(moves, fmt.Sprintf("%v: %d", move, n))
+		nodes += n
+	}
+	sort.Strings(moves)
+
+	for _, move := range moves {
+		fmt.Println(move)
+	}
+	fmt.Printf("\nNodes searched: %d\n", nodes)
+
+	return nil
 }
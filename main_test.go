@@ -0,0 +1,17 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerft_Errors(t *testing.T) {
+	fen := []string{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR", "w", "KQkq", "-", "0", "1"}
+
+	assert.Error(t, perft(nil))
+	assert.Error(t, perft(append(fen, "x")))
+	assert.Error(t, perft(append(fen, "0")))
+	assert.Error(t, perft(append(fen, "-1")))
+	assert.Error(t, perft([]string{"8/8/8 w - - 0 1", "1"}))
+}
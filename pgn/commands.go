@@ -0,0 +1,113 @@
+package pgn
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Engine evaluation of [%eval] command, from white's point of view
+type Eval struct {
+	Centipawns int
+	// moves to mate, negative when black mates & 0 when not a mate score
+	Mate int
+}
+
+// [%name value] embedded in comments
+var commandRegex = regexp.MustCompile(`\[%(\w+)\s+([^\]]*)\]`)
+
+// Extracts the clk & eval commands from the comment, other commands are left in the comment
+func (node *Node) parseCommands(comment string) string {
+	rest := commandRegex.ReplaceAllStringFunc(comment, func(command string) string {
+		match := commandRegex.FindStringSubmatch(command)
+		switch match[1] {
+		case "clk":
+			if clock, err := parseClock(match[2]); err == nil {
+				node.Clock = &clock
+				return ""
+			}
+		case "eval":
+			if eval, err := parseEval(match[2]); err == nil {
+				node.Eval = &eval
+				return ""
+			}
+		}
+		return command
+	})
+	return strings.Join(strings.Fields(rest), " ")
+}
+
+// Comment carrying the clk & eval commands of the node, empty if node has none
+func (node *Node) commands() string {
+	var commands []string
+	if node.Eval != nil {
+		commands = append(commands, "[%eval "+node.Eval.String()+"]")
+	}
+	if node.Clock != nil {
+		commands = append(commands, "[%clk "+formatClock(*node.Clock)+"]")
+	}
+	return strings.Join(commands, " ")
+}
+
+// H:MM:SS with optional fraction of seconds
+func parseClock(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("clock %q should be in H:MM:SS format", s)
+	}
+
+	hours, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hours in clock %q", s)
+	}
+	minutes, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil || minutes > 59 {
+		return 0, fmt.Errorf("invalid minutes in clock %q", s)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil || seconds < 0 || seconds >= 60 {
+		return 0, fmt.Errorf("invalid seconds in clock %q", s)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second)).Round(time.Millisecond), nil
+}
+
+func formatClock(d time.Duration) string {
+	hours, minutes := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	seconds := d % time.Minute
+	s := fmt.Sprintf("%d:%02d:%02d", hours, minutes, int(seconds/time.Second))
+	if fraction := seconds % time.Second; fraction != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%03d", fraction/time.Millisecond), "0")
+	}
+	return s
+}
+
+// pawns like -1.25 or mate like #-3, optionally followed by the search depth
+func parseEval(s string) (Eval, error) {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), ",")
+
+	if strings.HasPrefix(s, "#") {
+		mate, err := strconv.Atoi(s[1:])
+		if err != nil || mate == 0 {
+			return Eval{}, fmt.Errorf("invalid mate score %q", s)
+		}
+		return Eval{Mate: mate}, nil
+	}
+
+	pawns, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Eval{}, fmt.Errorf("invalid eval %q", s)
+	}
+	return Eval{Centipawns: int(math.Round(pawns * 100))}, nil
+}
+
+func (eval Eval) String() string {
+	if eval.Mate != 0 {
+		return fmt.Sprintf("#%d", eval.Mate)
+	}
+	return fmt.Sprintf("%.2f", float64(eval.Centipawns)/100)
+}
@@ -0,0 +1,68 @@
+package pgn
+
+import (
+	"time"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+)
+
+const StartingPositionFen = src.Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+
+// https://www.chessclub.com/help/PGN-spec
+// Tags every game should have, in export order
+var SevenTagRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Game of a pgn file, StartingFen is the initial position if empty
+type Game struct {
+	Tags        []Tag
+	StartingFen src.Fen
+	// comments of a game without moves
+	Comments []string
+	Moves    []*Node
+	// 1-0, 0-1, 1/2-1/2 or *
+	Result string
+}
+
+// Move of the game along with its annotations & alternatives
+type Node struct {
+	Move src.Move
+	SAN  string
+	// comments before the move, only at the start of a variation
+	PreComments []string
+	// comments after the move without the clk & eval commands
+	Comments []string
+	// remaining time of [%clk] command
+	Clock *time.Duration
+	// engine evaluation of [%eval] command
+	Eval *Eval
+	// numeric annotation glyphs, e.g. 1 for !
+	NAGs []int
+	// lines played instead of this move
+	Variations [][]*Node
+}
+
+// Value of the tag & whether it is present
+func (game *Game) Tag(name string) (string, bool) {
+	for _, tag := range game.Tags {
+		if tag.Name == name {
+			return tag.Value, true
+		}
+	}
+	return "", false
+}
+
+// Updates the tag or appends it if not present
+func (game *Game) SetTag(name, value string) {
+	for i := range game.Tags {
+		if game.Tags[i].Name == name {
+			game.Tags[i].Value = value
+			return
+		}
+	}
+	game.Tags = append(game.Tags, Tag{name, value})
+}
@@ -0,0 +1,180 @@
+package pgn
+
+import (
+	"io"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+)
+
+// Streaming reader of multi game pgn files
+type Reader struct {
+	s *scanner
+	// whether the current game reached its movetext section
+	movetext bool
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{s: newScanner(r)}
+}
+
+// Reads the next game, returns io.EOF when there are no more games
+// A malformed game is reported as *ParseError after skipping rest of the game,
+// so Next can be called again to read the following games
+func (r *Reader) Next() (*Game, error) {
+	game, err := r.readGame()
+	if err != nil && err != io.EOF {
+		r.skipGame()
+	}
+	return game, err
+}
+
+func (r *Reader) readGame() (*Game, error) {
+	r.movetext = false
+	tok, err := r.s.nextToken()
+	if err != nil {
+		return nil, err
+	}
+	if tok.tokenType == tokenEOF {
+		return nil, io.EOF
+	}
+
+	game := &Game{StartingFen: StartingPositionFen}
+	// token of the FEN tag if present, else first token of the movetext
+	var fenTok token
+
+	// tag pair section
+	for ; tok.tokenType == tokenTag; tok, err = r.s.nextToken() {
+		game.Tags = append(game.Tags, Tag{tok.value, tok.tagValue})
+		if tok.value == "FEN" {
+			game.StartingFen = src.Fen(tok.tagValue)
+			fenTok = tok
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.s.unreadToken(tok)
+	r.movetext = true
+
+	position, err := game.StartingFen.Parse()
+	if err != nil {
+		return nil, newParseError(fenTok.line, fenTok.column, "invalid starting position: %v", err)
+	}
+
+	// movetext section
+	game.Moves, game.Comments, err = r.readLine(game, position, 0)
+	if err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// Reads moves of a line till the result or, for variations, till the closing parenthesis
+// Returns the moves & comments which couldn't be attached to any move
+func (r *Reader) readLine(game *Game, position src.Position, depth int) ([]*Node, []string, error) {
+	var nodes []*Node
+	var comments []string
+	// position before the last move, variations start from it
+	var lastPosition src.Position
+
+	for {
+		tok, err := r.s.nextToken()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var last *Node
+		if len(nodes) > 0 {
+			last = nodes[len(nodes)-1]
+		}
+
+		switch tok.tokenType {
+		case tokenMoveNumber:
+			continue
+		case tokenComment:
+			if last != nil {
+				if comment := last.parseCommands(tok.value); comment != "" {
+					last.Comments = append(last.Comments, comment)
+				}
+			} else {
+				comments = append(comments, tok.value)
+			}
+		case tokenNAG:
+			if last == nil {
+				return nil, nil, newParseError(tok.line, tok.column, "annotation before any move")
+			}
+			last.NAGs = append(last.NAGs, tok.nag)
+		case tokenSymbol:
+			move, err := position.ParseSAN(tok.value)
+			if err != nil {
+				return nil, nil, newParseError(tok.line, tok.column, "%s: %v", tok.value, err)
+			}
+			node := &Node{
+				Move: move,
+				SAN:  position.SAN(move),
+			}
+			if last == nil {
+				node.PreComments, comments = comments, nil
+			}
+			nodes = append(nodes, node)
+
+			lastPosition = position
+			position.MakeMove(move)
+		case tokenOpenVariation:
+			if last == nil {
+				return nil, nil, newParseError(tok.line, tok.column, "variation before any move")
+			}
+			variationPosition := lastPosition
+			variation, _, err := r.readLine(game, variationPosition, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(variation) == 0 {
+				return nil, nil, newParseError(tok.line, tok.column, "empty variation")
+			}
+			last.Variations = append(last.Variations, variation)
+		case tokenCloseVariation:
+			if depth == 0 {
+				return nil, nil, newParseError(tok.line, tok.column, "unmatched )")
+			}
+			return nodes, comments, nil
+		case tokenResult:
+			if depth > 0 {
+				// result still ends the game
+				r.s.unreadToken(tok)
+				return nil, nil, newParseError(tok.line, tok.column, "unterminated variation")
+			}
+			game.Result = tok.value
+			return nodes, comments, nil
+		case tokenTag:
+			// next game started without the result of this one
+			r.s.unreadToken(tok)
+			return nil, nil, newParseError(tok.line, tok.column, "missing game termination marker")
+		case tokenEOF:
+			return nil, nil, newParseError(tok.line, tok.column, "missing game termination marker")
+		}
+	}
+}
+
+// Skips tokens till the end of the current game, i.e. result or tag section of the next game
+func (r *Reader) skipGame() {
+	for {
+		tok, err := r.s.nextToken()
+		if err != nil {
+			continue
+		}
+
+		switch tok.tokenType {
+		case tokenEOF, tokenResult:
+			return
+		case tokenTag:
+			// tags before the movetext belong to the current game
+			if r.movetext {
+				r.s.unreadToken(tok)
+				return
+			}
+		default:
+			r.movetext = true
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package pgn
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+	"github.com/stretchr/testify/assert"
+)
+
+const evergreenGame = `[Event "Casual Game"]
+[Site "Berlin GER"]
+[Date "1852.??.??"]
+[Round "?"]
+[White "Adolf Anderssen"]
+[Black "Jean Dufresne"]
+[Result "1-0"]
+[ECO "C52"]
+
+1. e4 e5 2. Nf3 Nc6 3. Bc4 Bc5 4. b4 {Evans Gambit} Bxb4 5. c3 Ba5 6. d4 exd4
+7. O-O d3 8. Qb3 Qf6 9. e5 Qg6 10. Re1 Nge7 11. Ba3 b5 12. Qxb5 Rb8 13. Qa4
+Bb6 14. Nbd2 Bb7 15. Ne4 Qf5 16. Bxd3 Qh5 17. Nf6+ gxf6 18. exf6 Rg8 19. Rad1
+Qxf3 20. Rxe7+ Nxe7 21. Qxd7+ Kxd7 22. Bf5+ Ke8 23. Bd7+ Kf8 24. Bxe7# 1-0
+`
+
+const annotatedGame = `% escaped line which is ignored
+[Event "Annotated \"quoted\" \\ game"]
+
+{Opening comment} 1. e4! $14 e5 (1... c5 {Sicilian} 2. Nf3 (2. c3 d5) d6)
+(1... e6) 2. Nf3?! ; line comment
+Nc6 1/2-1/2
+`
+
+const setUpGame = `[SetUp "1"]
+[FEN "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"]
+
+1... Kd7 2. e4 *
+`
+
+func sanLine(nodes []*Node) (sans []string) {
+	for _, node := range nodes {
+		sans = append(sans, node.SAN)
+	}
+	return
+}
+
+func TestReader(t *testing.T) {
+	r := NewReader(strings.NewReader(evergreenGame + "\n" + annotatedGame + "\n" + setUpGame))
+
+	t.Run("seven tag roster", func(t *testing.T) {
+		game, err := r.Next()
+		assert.NoError(t, err)
+		for i, name := range SevenTagRoster {
+			assert.Equal(t, name, game.Tags[i].Name)
+		}
+		value, ok := game.Tag("ECO")
+		assert.True(t, ok)
+		assert.Equal(t, "C52", value)
+		_, ok = game.Tag("Annotator")
+		assert.False(t, ok)
+
+		assert.Equal(t, StartingPositionFen, game.StartingFen)
+		assert.Len(t, game.Moves, 47)
+		assert.Equal(t, "Bxe7#", game.Moves[46].SAN)
+		assert.Equal(t, []string{"Evans Gambit"}, game.Moves[6].Comments)
+		assert.Equal(t, "1-0", game.Result)
+	})
+
+	t.Run("comments, nags & variations", func(t *testing.T) {
+		game, err := r.Next()
+		assert.NoError(t, err)
+		value, _ := game.Tag("Event")
+		assert.Equal(t, `Annotated "quoted" \ game`, value)
+
+		assert.Equal(t, []string{"e4", "e5", "Nf3", "Nc6"}, sanLine(game.Moves))
+		assert.Equal(t, []string{"Opening comment"}, game.Moves[0].PreComments)
+		assert.Equal(t, []int{1, 14}, game.Moves[0].NAGs)
+		assert.Equal(t, []int{6}, game.Moves[2].NAGs)
+		assert.Equal(t, []string{"line comment"}, game.Moves[2].Comments)
+
+		variations := game.Moves[1].Variations
+		assert.Len(t, variations, 2)
+		assert.Equal(t, []string{"c5", "Nf3", "d6"}, sanLine(variations[0]))
+		assert.Equal(t, []string{"Sicilian"}, variations[0][0].Comments)
+		assert.Equal(t, []string{"e6"}, sanLine(variations[1]))
+
+		nested := variations[0][1].Variations
+		assert.Len(t, nested, 1)
+		assert.Equal(t, []string{"c3", "d5"}, sanLine(nested[0]))
+		assert.Equal(t, "1/2-1/2", game.Result)
+	})
+
+	t.Run("set up position", func(t *testing.T) {
+		game, err := r.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, src.Fen("4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"), game.StartingFen)
+		assert.Equal(t, []string{"Kd7", "e4"}, sanLine(game.Moves))
+		assert.Equal(t, "*", game.Result)
+	})
+
+	t.Run("end of file", func(t *testing.T) {
+		_, err := r.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func TestReader_Errors(t *testing.T) {
+	// RETC = Reader Errors Test Cases
+	type RETC struct {
+		desc           string
+		pgn            string
+		expectedLine   int
+		expectedColumn int
+	}
+
+	tcs := []RETC{
+		{"illegal move", "1. e4 e5 2. Nf6 Nc6 *", 1, 13},
+		{"illegal move in variation", "1. e4 e5 (1... d5 2. exd5 Qxd5 3. Qxd5) *", 1, 35},
+		{"unmatched closing parenthesis", "1. e4 ) e5 *", 1, 7},
+		{"unterminated variation", "1. e4 (1. d4 *", 1, 14},
+		{"empty variation", "1. e4 () e5 *", 1, 7},
+		{"annotation before any move", "$1 1. e4 *", 1, 1},
+		{"invalid nag", "1. e4 $x *", 1, 7},
+		{"unexpected character", "1. e4 & e5 *", 1, 7},
+		{"missing result", "1. e4 e5", 3, 1},
+		{"unterminated tag value", "[Event \"x]\n[Site \"y\"]\n\n1. e4 *", 1, 1},
+		{"tag without closing bracket", "[Event \"x\"\n\n1. e4 *", 3, 1},
+		{"invalid starting position", "[Event \"x\"]\n[FEN \"8/8/8/8/8/8/8/8 w - - 0 1\"]\n\n1. e4 *", 2, 1},
+		{"castling rights without rook in starting position", "[Event \"x\"]\n[FEN \"4k3/8/8/8/8/8/8/4K3 w K - 0 1\"]\n\n1. O-O *", 2, 1},
+		{"en passant target without pawn in starting position", "[Event \"x\"]\n[FEN \"4k3/8/8/3P4/8/8/8/4K3 w - e6 0 1\"]\n\n1. dxe6 *", 2, 1},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tc.pgn + "\n\n[Event \"next\"]\n\n1. d4 *\n"))
+
+			_, err := r.Next()
+			if assert.IsType(t, &ParseError{}, err) {
+				pe := err.(*ParseError)
+				assert.Equal(t, tc.expectedLine, pe.Line, pe.Error())
+				assert.Equal(t, tc.expectedColumn, pe.Column, pe.Error())
+			}
+
+			// rest of the file is still readable
+			game, err := r.Next()
+			assert.NoError(t, err)
+			if assert.NotNil(t, game) {
+				value, _ := game.Tag("Event")
+				assert.Equal(t, "next", value)
+				assert.Equal(t, []string{"d4"}, sanLine(game.Moves))
+			}
+
+			_, err = r.Next()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
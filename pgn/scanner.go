@@ -0,0 +1,262 @@
+package pgn
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenTag
+	tokenComment
+	tokenNAG
+	tokenMoveNumber
+	tokenSymbol
+	tokenResult
+	tokenOpenVariation
+	tokenCloseVariation
+)
+
+type token struct {
+	tokenType tokenType
+	// tag name, comment text, symbol or result
+	value string
+	// tag value of tag token
+	tagValue string
+	// nag of nag token
+	nag int
+
+	line, column int
+}
+
+// Error in a malformed game along with the position where it was found
+type ParseError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (pe *ParseError) Error() string {
+	return fmt.Sprintf("pgn line %d, column %d: %s", pe.Line, pe.Column, pe.Msg)
+}
+
+func newParseError(line, column int, format string, a ...interface{}) *ParseError {
+	return &ParseError{
+		Line:   line,
+		Column: column,
+		Msg:    fmt.Sprintf(format, a...),
+	}
+}
+
+// suffix annotations and their nags
+var suffixAnnotations = map[string]int{
+	"!":  1,
+	"?":  2,
+	"!!": 3,
+	"??": 4,
+	"!?": 5,
+	"?!": 6,
+}
+
+// Splits pgn into tokens, tracking line & column of every token
+type scanner struct {
+	r *bufio.Reader
+
+	line, column         int
+	prevLine, prevColumn int
+
+	// token pushed back by the parser
+	unread *token
+}
+
+func newScanner(r io.Reader) *scanner {
+	return &scanner{
+		r:    bufio.NewReader(r),
+		line: 1,
+	}
+}
+
+func (s *scanner) readRune() (rune, bool) {
+	c, _, err := s.r.ReadRune()
+	if err != nil {
+		return 0, false
+	}
+
+	s.prevLine, s.prevColumn = s.line, s.column
+	if c == '\n' {
+		s.line++
+		s.column = 0
+	} else {
+		s.column++
+	}
+	return c, true
+}
+
+func (s *scanner) unreadRune() {
+	if s.r.UnreadRune() == nil {
+		s.line, s.column = s.prevLine, s.prevColumn
+	}
+}
+
+func (s *scanner) unreadToken(tok token) {
+	s.unread = &tok
+}
+
+func isSymbolRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("_+#=:-/", c)
+}
+
+func (s *scanner) nextToken() (token, error) {
+	if s.unread != nil {
+		tok := *s.unread
+		s.unread = nil
+		return tok, nil
+	}
+
+	for {
+		c, ok := s.readRune()
+		if !ok {
+			return token{tokenType: tokenEOF, line: s.line, column: s.column + 1}, nil
+		}
+		if unicode.IsSpace(c) {
+			continue
+		}
+
+		tok := token{line: s.line, column: s.column}
+		switch {
+		case c == '%' && s.column == 1:
+			// escaped line
+			s.readUntil('\n')
+		case c == '[':
+			return s.readTag(tok)
+		case c == '{':
+			comment, ok := s.readUntil('}')
+			if !ok {
+				return tok, newParseError(tok.line, tok.column, "unterminated comment")
+			}
+			tok.tokenType, tok.value = tokenComment, strings.TrimSpace(comment)
+			return tok, nil
+		case c == ';':
+			comment, _ := s.readUntil('\n')
+			tok.tokenType, tok.value = tokenComment, strings.TrimSpace(comment)
+			return tok, nil
+		case c == '(':
+			tok.tokenType = tokenOpenVariation
+			return tok, nil
+		case c == ')':
+			tok.tokenType = tokenCloseVariation
+			return tok, nil
+		case c == '*':
+			tok.tokenType, tok.value = tokenResult, "*"
+			return tok, nil
+		case c == '$':
+			digits := s.readWhile(unicode.IsDigit)
+			nag, err := strconv.Atoi(digits)
+			if err != nil || nag > 255 {
+				return tok, newParseError(tok.line, tok.column, "invalid nag $%s", digits)
+			}
+			tok.tokenType, tok.nag = tokenNAG, nag
+			return tok, nil
+		case c == '!' || c == '?':
+			annotation := string(c) + s.readWhile(func(c rune) bool { return c == '!' || c == '?' })
+			nag, ok := suffixAnnotations[annotation]
+			if !ok {
+				return tok, newParseError(tok.line, tok.column, "invalid annotation %s", annotation)
+			}
+			tok.tokenType, tok.nag = tokenNAG, nag
+			return tok, nil
+		case c == '.':
+			// dots of a move number separated by space
+			s.readWhile(func(c rune) bool { return c == '.' })
+		case isSymbolRune(c):
+			symbol := string(c) + s.readWhile(isSymbolRune)
+			switch {
+			case symbol == "1-0" || symbol == "0-1" || symbol == "1/2-1/2":
+				tok.tokenType, tok.value = tokenResult, symbol
+			case strings.Trim(symbol, "0123456789") == "":
+				tok.tokenType, tok.value = tokenMoveNumber, symbol
+				s.readWhile(func(c rune) bool { return c == '.' })
+			default:
+				tok.tokenType, tok.value = tokenSymbol, symbol
+			}
+			return tok, nil
+		default:
+			return tok, newParseError(tok.line, tok.column, "unexpected character %q", c)
+		}
+	}
+}
+
+// [Name "Value"]
+func (s *scanner) readTag(tok token) (token, error) {
+	s.readWhile(unicode.IsSpace)
+	name := s.readWhile(func(c rune) bool { return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' })
+	if name == "" {
+		return tok, newParseError(s.line, s.column+1, "tag name expected")
+	}
+
+	s.readWhile(unicode.IsSpace)
+	if c, ok := s.readRune(); !ok || c != '"' {
+		return tok, newParseError(s.line, s.column, "tag value of %s should start with \"", name)
+	}
+
+	var value strings.Builder
+	for {
+		c, ok := s.readRune()
+		if !ok || c == '\n' {
+			return tok, newParseError(tok.line, tok.column, "unterminated value of tag %s", name)
+		}
+		if c == '"' {
+			break
+		}
+		if c == '\\' {
+			if c, ok = s.readRune(); !ok {
+				return tok, newParseError(tok.line, tok.column, "unterminated value of tag %s", name)
+			}
+		}
+		value.WriteRune(c)
+	}
+
+	s.readWhile(unicode.IsSpace)
+	if c, ok := s.readRune(); !ok || c != ']' {
+		return tok, newParseError(s.line, s.column, "tag %s should end with ]", name)
+	}
+
+	tok.tokenType, tok.value, tok.tagValue = tokenTag, name, value.String()
+	return tok, nil
+}
+
+// Reads till the delimiter which is consumed but not returned, false if input ended before it
+func (s *scanner) readUntil(delim rune) (string, bool) {
+	var sb strings.Builder
+	for {
+		c, ok := s.readRune()
+		if !ok {
+			return sb.String(), false
+		}
+		if c == delim {
+			return sb.String(), true
+		}
+		sb.WriteRune(c)
+	}
+}
+
+func (s *scanner) readWhile(f func(rune) bool) string {
+	var sb strings.Builder
+	for {
+		c, ok := s.readRune()
+		if !ok {
+			return sb.String()
+		}
+		if !f(c) {
+			s.unreadRune()
+			return sb.String()
+		}
+		sb.WriteRune(c)
+	}
+}
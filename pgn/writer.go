@@ -0,0 +1,218 @@
+package pgn
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+)
+
+// maximum length of a movetext line in export format
+const maxLineLength = 80
+
+// Writes games in export format separated by blank lines
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) Write(game *Game) error {
+	s, err := game.export()
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w.w, s+"\n")
+	return err
+}
+
+// Export format pgn of the game
+func (game *Game) String() string {
+	s, err := game.export()
+	if err != nil {
+		return err.Error()
+	}
+	return s
+}
+
+func (game *Game) export() (string, error) {
+	startingFen := game.StartingFen
+	if startingFen == "" {
+		startingFen = StartingPositionFen
+	}
+	position, err := startingFen.Parse()
+	if err != nil {
+		return "", err
+	}
+
+	result := game.Result
+	if result == "" {
+		result, _ = game.Tag("Result")
+	}
+	if result == "" {
+		result = "*"
+	}
+
+	var sb strings.Builder
+	for _, tag := range game.exportTags(startingFen, result) {
+		fmt.Fprintf(&sb, "[%s \"%s\"]\n", tag.Name, escapeTagValue(tag.Value))
+	}
+	sb.WriteString("\n")
+
+	mw := movetextWriter{}
+	for _, comment := range game.Comments {
+		mw.comment(comment)
+	}
+
+	mw.line(game.Moves, position, position.FullMoveNumber(), position.SideToMove() == src.White)
+	mw.word(result)
+
+	for _, line := range mw.wrap() {
+		sb.WriteString(line + "\n")
+	}
+	return sb.String(), nil
+}
+
+// Seven tag roster in its order followed by SetUp & FEN for a set up position
+// and then other tags sorted by name
+func (game *Game) exportTags(startingFen src.Fen, result string) (tags []Tag) {
+	defaults := map[string]string{
+		"Event":  "?",
+		"Site":   "?",
+		"Date":   "????.??.??",
+		"Round":  "?",
+		"White":  "?",
+		"Black":  "?",
+		"Result": result,
+	}
+	for _, name := range SevenTagRoster {
+		value, ok := game.Tag(name)
+		if !ok || name == "Result" {
+			value = defaults[name]
+		}
+		tags = append(tags, Tag{name, value})
+	}
+
+	if startingFen != StartingPositionFen {
+		tags = append(tags, Tag{"SetUp", "1"}, Tag{"FEN", string(startingFen)})
+	}
+
+	var others []Tag
+	for _, tag := range game.Tags {
+		if _, ok := defaults[tag.Name]; !ok && tag.Name != "SetUp" && tag.Name != "FEN" {
+			others = append(others, tag)
+		}
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		return others[i].Name < others[j].Name
+	})
+	return append(tags, others...)
+}
+
+func escapeTagValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// Builds movetext as words which are wrapped into lines at the end
+type movetextWriter struct {
+	words []string
+	// opening parentheses to prefix to the next word
+	open string
+}
+
+func (mw *movetextWriter) word(w string) {
+	mw.words = append(mw.words, mw.open+w)
+	mw.open = ""
+}
+
+// commands are kept on a single line
+var commentWordRegex = regexp.MustCompile(`\[%[^\]]*\]|\S+`)
+
+func (mw *movetextWriter) comment(comment string) {
+	words := commentWordRegex.FindAllString(strings.ReplaceAll(comment, "}", ""), -1)
+	if len(words) == 0 {
+		mw.word("{}")
+		return
+	}
+	words[0] = "{" + words[0]
+	words[len(words)-1] += "}"
+	for _, w := range words {
+		mw.word(w)
+	}
+}
+
+// Writes moves of the line starting from the position, along with their annotations & variations
+func (mw *movetextWriter) line(nodes []*Node, position src.Position, fullMoveNumber int, white bool) {
+	// black's move number is written at the start of a line or after comments & variations
+	moveNumberNeeded := true
+
+	for _, node := range nodes {
+		for _, comment := range node.PreComments {
+			mw.comment(comment)
+			moveNumberNeeded = true
+		}
+
+		if white {
+			mw.word(fmt.Sprintf("%d.", fullMoveNumber))
+		} else if moveNumberNeeded {
+			mw.word(fmt.Sprintf("%d...", fullMoveNumber))
+		}
+		mw.word(position.SAN(node.Move))
+		moveNumberNeeded = false
+
+		for _, nag := range node.NAGs {
+			mw.word(fmt.Sprintf("$%d", nag))
+		}
+
+		comments := node.Comments
+		if commands := node.commands(); commands != "" {
+			if len(comments) > 0 {
+				comments = append([]string{commands + " " + comments[0]}, comments[1:]...)
+			} else {
+				comments = []string{commands}
+			}
+		}
+		for _, comment := range comments {
+			mw.comment(comment)
+			moveNumberNeeded = true
+		}
+
+		for _, variation := range node.Variations {
+			if len(variation) == 0 {
+				continue
+			}
+			variationPosition := position
+			mw.open += "("
+			mw.line(variation, variationPosition, fullMoveNumber, white)
+			mw.words[len(mw.words)-1] += ")"
+			moveNumberNeeded = true
+		}
+
+		position.MakeMove(node.Move)
+		if !white {
+			fullMoveNumber++
+		}
+		white = !white
+	}
+}
+
+// Joins the words into lines not longer than maxLineLength
+func (mw *movetextWriter) wrap() (lines []string) {
+	var line string
+	for _, w := range mw.words {
+		if line != "" && len(line)+1+len(w) > maxLineLength {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	return append(lines, line)
+}
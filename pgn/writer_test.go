@@ -0,0 +1,138 @@
+package pgn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameString(t *testing.T) {
+	input := `[White "Alice"]
+[Event "Test"]
+[Annotator "me"]
+[Result "1/2-1/2"]
+
+{Opening comment} 1. e4! $14 {[%eval 0.35] [%clk 0:05:00]} e5 {[%clk 0:04:58.5] solid}
+(1... c5 {Sicilian} 2. Nf3 (2. c3 d5) d6) (1... e6) 2. Nf3?! Nc6 1/2-1/2
+`
+	expected := `[Event "Test"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "Alice"]
+[Black "?"]
+[Result "1/2-1/2"]
+[Annotator "me"]
+
+{Opening comment} 1. e4 $1 $14 {[%eval 0.35] [%clk 0:05:00]} 1... e5
+{[%clk 0:04:58.5] solid} (1... c5 {Sicilian} 2. Nf3 (2. c3 d5) 2... d6) (1...
+e6) 2. Nf3 $6 Nc6 1/2-1/2
+`
+
+	game, err := NewReader(strings.NewReader(input)).Next()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, game.String())
+}
+
+func TestGameString_SetUp(t *testing.T) {
+	startingFen := src.Fen("4k3/8/8/8/8/8/4P3/4K3 b - - 0 1")
+	position, _ := startingFen.Parse()
+
+	game := &Game{
+		Tags:        []Tag{{"Black", "Bob"}, {"SetUp", "0"}},
+		StartingFen: startingFen,
+	}
+	for _, san := range []string{"Kd7", "e4", "Ke6"} {
+		move, err := position.ParseSAN(san)
+		assert.NoError(t, err)
+		game.Moves = append(game.Moves, &Node{Move: move})
+		position.MakeMove(move)
+	}
+	eval := Eval{Mate: -12}
+	game.Moves[1].Eval = &eval
+
+	expected := `[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "Bob"]
+[Result "*"]
+[SetUp "1"]
+[FEN "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"]
+
+1... Kd7 2. e4 {[%eval #-12]} 2... Ke6 *
+`
+	assert.Equal(t, expected, game.String())
+
+	// set up tags are dropped for a game from the initial position
+	game = &Game{Tags: []Tag{{"SetUp", "1"}, {"FEN", string(StartingPositionFen)}}}
+	assert.NotContains(t, game.String(), "SetUp")
+	assert.NotContains(t, game.String(), "FEN")
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	var games []*Game
+	r := NewReader(strings.NewReader(evergreenGame + "\n" + annotatedGame + "\n" + setUpGame))
+	for game, err := r.Next(); err == nil; game, err = r.Next() {
+		games = append(games, game)
+		assert.NoError(t, w.Write(game))
+	}
+	assert.Len(t, games, 3)
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+		assert.LessOrEqual(t, len(line), maxLineLength, line)
+	}
+
+	r = NewReader(&buf)
+	for _, expected := range games {
+		game, err := r.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, expected.StartingFen, game.StartingFen)
+		assert.Equal(t, expected.Comments, game.Comments)
+		assert.Equal(t, expected.Moves, game.Moves)
+		assert.Equal(t, expected.Result, game.Result)
+	}
+}
+
+func TestParseCommands(t *testing.T) {
+	// PCTC = Parse Commands Test Cases
+	type PCTC struct {
+		desc            string
+		comment         string
+		expectedComment string
+		expectedClock   *time.Duration
+		expectedEval    *Eval
+	}
+
+	duration := func(d time.Duration) *time.Duration { return &d }
+
+	tcs := []PCTC{
+		{"plain comment", "good move", "good move", nil, nil},
+		{"clock", "[%clk 1:02:03]", "", duration(time.Hour + 2*time.Minute + 3*time.Second), nil},
+		{"clock with tenths", "[%clk 0:00:09.7] hurry", "hurry", duration(9700 * time.Millisecond), nil},
+		{"eval", "[%eval -1.25]", "", nil, &Eval{Centipawns: -125}},
+		{"eval with depth", "[%eval 0.17,23]", "", nil, &Eval{Centipawns: 17}},
+		{"mate", "[%eval #-3]", "", nil, &Eval{Mate: -3}},
+		{"both commands", "best [%eval 2.00] [%clk 0:01:00] line", "best line", duration(time.Minute), &Eval{Centipawns: 200}},
+		{"unknown command", "[%emt 0:00:05]", "[%emt 0:00:05]", nil, nil},
+		{"malformed clock", "[%clk 1:60:00]", "[%clk 1:60:00]", nil, nil},
+		{"malformed eval", "[%eval #0]", "[%eval #0]", nil, nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			node := &Node{}
+			assert.Equal(t, tc.expectedComment, node.parseCommands(tc.comment))
+			assert.Equal(t, tc.expectedClock, node.Clock)
+			assert.Equal(t, tc.expectedEval, node.Eval)
+		})
+	}
+}
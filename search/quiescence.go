@@ -0,0 +1,112 @@
+package search
+
+import "github.com/bhavya5jain/go-django-unchained/src"
+
+// Margin over the material gain of a capture for delta pruning, covers positional gains of the capture
+const deltaMargin = 200
+
+// Score of the quiet position from the point of view of the side to move
+// Only captures & queen promotions are searched unless the side to move is in check
+func (s *Search) quiescence(position *src.Position, ply, alpha, beta int) int {
+	if s.stop() {
+		return 0
+	}
+	s.nodes++
+
+	inCheck := position.InCheck()
+	moves := src.GenerateAllMoves(*position, 0)
+	if len(moves) == 0 {
+		if inCheck {
+			return -MateScore + ply
+		}
+		return 0
+	}
+
+	if ply >= MaxPly {
+		return s.evaluator.Evaluate(*position)
+	}
+
+	// the side to move can stand pat unless it is in check, then all evasions are searched
+	standPat := -Infinity
+	if !inCheck {
+		standPat = s.evaluator.Evaluate(*position)
+		if standPat >= beta {
+			return beta
+		}
+		if standPat > alpha {
+			alpha = standPat
+		}
+		moves = tacticalMoves(moves)
+	}
+	orderByMVVLVA(*position, moves)
+
+	for _, move := range moves {
+		// captures which can't raise alpha even with a margin are skipped
+		if !inCheck && standPat+materialGain(*position, move)+deltaMargin <= alpha {
+			continue
+		}
+
+		undo := position.MakeMove(move)
+		score := -s.quiescence(position, ply+1, -beta, -alpha)
+		position.UnmakeMove(undo)
+
+		if s.stopped {
+			return 0
+		}
+
+		if score > alpha {
+			alpha = score
+			if alpha >= beta {
+				return beta
+			}
+		}
+	}
+
+	return alpha
+}
+
+// Captures & queen promotions of the moves, filtered in place
+func tacticalMoves(moves []src.Move) []src.Move {
+	tactical := moves[:0]
+	for _, move := range moves {
+		if move.IsCapture() || move.PromotionPieceType() == src.Queen {
+			tactical = append(tactical, move)
+		}
+	}
+	return tactical
+}
+
+// Material won by the move in centipawns
+func materialGain(position src.Position, move src.Move) int {
+	gain := src.PieceValue(position.CapturedPiece(move))
+	if pt := move.PromotionPieceType(); pt != 0 {
+		gain += src.PieceValue(pt) - src.PieceValue(src.Pawn)
+	}
+	return gain
+}
+
+// Most valuable victim - least valuable attacker score of the move, 0 for quiet moves
+// A promotion counts as capturing the material it gains
+func mvvLva(position src.Position, move src.Move) int {
+	gain := materialGain(position, move)
+	if gain == 0 {
+		return 0
+	}
+	return gain*int(src.TotalPieceTypes) - int(position.PieceAt(move.From()).Type())
+}
+
+// Sorts the moves by their MVV-LVA score, quiet moves keep their order at the end
+func orderByMVVLVA(position src.Position, moves []src.Move) {
+	scores := make([]int, len(moves))
+	for i, move := range moves {
+		scores[i] = mvvLva(position, move)
+	}
+
+	// insertion sort, move lists are short
+	for i := 1; i < len(moves); i++ {
+		for j := i; j > 0 && scores[j] > scores[j-1]; j-- {
+			moves[j], moves[j-1] = moves[j-1], moves[j]
+			scores[j], scores[j-1] = scores[j-1], scores[j]
+		}
+	}
+}
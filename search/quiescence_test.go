@@ -0,0 +1,63 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch_Tactics(t *testing.T) {
+	// TTC = Tactics Test Cases
+	type TTC struct {
+		desc             string
+		positionFen      src.Fen
+		expectedBestMove string
+		nodeBudget       uint64
+	}
+
+	tcs := []TTC{
+		{"capture the undefended piece, not the defended one", "4k3/8/4p3/3r4/b7/8/8/3QK3 w - - 0 1", "d1a4", 100},
+		{"knight fork", "q3k3/8/8/1N6/8/8/8/4K3 w - - 0 1", "b5c7", 100},
+		{"skewer", "4q3/8/8/4k3/8/8/8/R5K1 w - - 0 1", "a1e1", 100},
+		{"promotion", "8/P7/8/8/8/8/k7/4K3 w - - 0 1", "a7a8q", 100},
+		{"capture with the pawn, not the queen", "4k3/8/2p5/3n4/4P3/8/3Q4/4K3 w - - 0 1", "e4d5", 100},
+		{"exchange sequence on one square", "4k3/3r4/8/3n4/8/8/3R4/3RK3 w - - 0 1", "d2d5", 100},
+		{"mate in 2", "r6k/6pp/8/8/8/8/4Q3/4R1K1 w - - 0 1", "e2e8", 5000},
+		{"back rank mate", "6k1/5ppp/8/8/8/8/5PPP/3R2K1 w - - 0 1", "d1d8", 100},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.positionFen.Parse()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			solvedAt := uint64(0)
+			NewSearch(src.MaterialEvaluator{}, NewTranspositionTable(1), func(info SearchInfo) {
+				if solvedAt == 0 && info.PV[0].UCI() == tc.expectedBestMove {
+					solvedAt = info.Nodes
+					cancel()
+				}
+			}).Run(ctx, position, 8)
+
+			if assert.NotZero(t, solvedAt, "not solved") {
+				assert.LessOrEqual(t, solvedAt, tc.nodeBudget)
+			}
+		})
+	}
+}
+
+func TestOrderByMVVLVA(t *testing.T) {
+	// pawn, knight & queen can capture the rook, knight & queen can capture the pawn
+	position, _ := src.Fen("4k3/8/8/2r5/1P3p2/3N4/8/2Q1K3 w - - 0 1").Parse()
+	moves := src.GenerateAllMoves(position, 0)
+	orderByMVVLVA(position, moves)
+
+	ordered := []string{}
+	for _, move := range moves[:5] {
+		ordered = append(ordered, move.UCI())
+	}
+	assert.Equal(t, []string{"b4c5", "d3c5", "c1c5", "d3f4", "c1f4"}, ordered)
+}
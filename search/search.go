@@ -0,0 +1,236 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+)
+
+// Evaluates the position in centipawns from the point of view of the side to move
+type PositionEvaluator interface {
+	Evaluate(position src.Position) int
+}
+
+const (
+	// bound of all scores
+	Infinity = 32000
+	// score of checkmating at the root, checkmate at ply n scores MateScore - n
+	MateScore = 31000
+	// maximum depth of the search
+	MaxPly = 128
+
+	// nodes searched between checks of the stop signal
+	stopCheckInterval = 2048
+	// size of the transposition table of a search created without one
+	defaultTTSizeMB = 16
+)
+
+// Report of a completed iteration of the search
+type SearchInfo struct {
+	Depth int
+	// centipawns from the point of view of the side to move, 0 for mate scores
+	Score int
+	// moves till checkmate, negative if the side to move gets checkmated & 0 if score is not a mate score
+	Mate  int
+	Nodes uint64
+	// nodes per second
+	NPS uint64
+	// permille of the transposition table used by the search
+	Hashfull int
+	Time     time.Duration
+	// principal variation, best move is its first move
+	PV []src.Move
+}
+
+// Negamax alpha-beta search with iterative deepening
+type Search struct {
+	evaluator PositionEvaluator
+	// shared by the searches of a game
+	tt *TranspositionTable
+	// called after every completed iteration
+	report func(SearchInfo)
+
+	ctx     context.Context
+	stopped bool
+	nodes   uint64
+	// principal variation of the previous iteration, its moves are searched first
+	pv []src.Move
+}
+
+// Search with its own transposition table of defaultTTSizeMB megabytes if tt is nil
+func NewSearch(evaluator PositionEvaluator, tt *TranspositionTable, report func(SearchInfo)) *Search {
+	if tt == nil {
+		tt = NewTranspositionTable(defaultTTSizeMB)
+	}
+	return &Search{
+		evaluator: evaluator,
+		tt:        tt,
+		report:    report,
+	}
+}
+
+// Deepens the search till maxDepth or till ctx is done & returns the report of the deepest completed iteration
+// PV of the report is empty if the position has no legal moves
+func (s *Search) Run(ctx context.Context, position src.Position, maxDepth int) SearchInfo {
+	if maxDepth <= 0 || maxDepth > MaxPly {
+		maxDepth = MaxPly
+	}
+	s.ctx, s.stopped, s.nodes, s.pv = ctx, false, 0, nil
+	s.tt.nextAge()
+
+	moves := src.GenerateAllMoves(position, 0)
+	if len(moves) == 0 {
+		return SearchInfo{}
+	}
+
+	// best move even if the first iteration is interrupted
+	best := SearchInfo{PV: moves[:1]}
+	start := time.Now()
+
+	for depth := 1; depth <= maxDepth; depth++ {
+		var pv []src.Move
+		score := s.negamax(&position, depth, 0, -Infinity, Infinity, &pv)
+		if s.stopped {
+			break
+		}
+		s.pv = pv
+
+		elapsed := time.Since(start)
+		best = SearchInfo{
+			Depth:    depth,
+			Nodes:    s.nodes,
+			NPS:      uint64(float64(s.nodes) / elapsed.Seconds()),
+			Hashfull: s.tt.Hashfull(),
+			Time:     elapsed,
+			PV:       pv,
+		}
+		best.Score, best.Mate = scoreToMate(score)
+
+		if s.report != nil {
+			s.report(best)
+		}
+
+		// deeper iterations can't find a shorter mate
+		if best.Mate != 0 && 2*abs(best.Mate)-1 <= depth {
+			break
+		}
+	}
+
+	return best
+}
+
+// Score of the position from the point of view of the side to move, pv is set to the principal variation
+func (s *Search) negamax(position *src.Position, depth, ply, alpha, beta int, pv *[]src.Move) int {
+	if depth == 0 {
+		return s.quiescence(position, ply, alpha, beta)
+	}
+
+	if s.stop() {
+		return 0
+	}
+	s.nodes++
+
+	moves := src.GenerateAllMoves(*position, 0)
+	if len(moves) == 0 {
+		if position.InCheck() {
+			// checkmated, prefer the longest way to get checkmated
+			return -MateScore + ply
+		}
+		return 0
+	}
+
+	if ply >= MaxPly {
+		return s.evaluator.Evaluate(*position)
+	}
+
+	key := position.ZobristKey()
+	ttMove := src.Move(0)
+	if entry, ok := s.tt.probe(key, ply); ok {
+		ttMove = entry.move
+		// the root is always searched to get its best move
+		if ply > 0 && int(entry.depth) >= depth {
+			score := int(entry.score)
+			switch {
+			case entry.bound == exactBound:
+				return score
+			case entry.bound == lowerBound && score >= beta:
+				return beta
+			case entry.bound == upperBound && score <= alpha:
+				return alpha
+			}
+		}
+	}
+
+	s.orderMoves(*position, moves, ply, ttMove)
+
+	b := upperBound
+	bestMove := src.Move(0)
+	for _, move := range moves {
+		var childPV []src.Move
+		undo := position.MakeMove(move)
+		score := -s.negamax(position, depth-1, ply+1, -beta, -alpha, &childPV)
+		position.UnmakeMove(undo)
+
+		if s.stopped {
+			return 0
+		}
+
+		if score > alpha {
+			alpha, bestMove, b = score, move, exactBound
+			*pv = append([]src.Move{move}, childPV...)
+			if alpha >= beta {
+				s.tt.store(key, move, beta, depth, ply, lowerBound)
+				return beta
+			}
+		}
+	}
+
+	s.tt.store(key, bestMove, alpha, depth, ply, b)
+	return alpha
+}
+
+// Orders captures by MVV-LVA & moves the best move of the transposition table
+// or else the move of the previous principal variation at the ply to the front
+func (s *Search) orderMoves(position src.Position, moves []src.Move, ply int, ttMove src.Move) {
+	orderByMVVLVA(position, moves)
+
+	first := ttMove
+	if first == 0 && ply < len(s.pv) {
+		first = s.pv[ply]
+	}
+	for i, move := range moves {
+		if move == first {
+			copy(moves[1:i+1], moves[:i])
+			moves[0] = move
+			return
+		}
+	}
+}
+
+// Checks the stop signal every stopCheckInterval nodes
+func (s *Search) stop() bool {
+	if !s.stopped && s.nodes%stopCheckInterval == 0 && s.ctx.Err() != nil {
+		s.stopped = true
+	}
+	return s.stopped
+}
+
+// Centipawns or moves till mate of the score
+func scoreToMate(score int) (int, int) {
+	switch {
+	case score >= MateScore-MaxPly:
+		return 0, (MateScore - score + 1) / 2
+	case score <= -MateScore+MaxPly:
+		return 0, -(MateScore + score) / 2
+	default:
+		return score, 0
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	// STC = Search Test Cases
+	type STC struct {
+		desc             string
+		positionFen      src.Fen
+		depth            int
+		expectedBestMove string
+		expectedMate     int
+	}
+
+	tcs := []STC{
+		{"mate in 1", "6k1/5ppp/8/8/8/8/8/R5K1 w - - 0 1", 3, "a1a8", 1},
+		{"mate in 2", "r6k/6pp/8/8/8/8/4Q3/4R1K1 w - - 0 1", 4, "e2e8", 2},
+		{"getting mated in 1", "7k/R7/6K1/8/8/8/8/8 b - - 0 1", 3, "h8g8", -1},
+		{"win the queen", "4k3/8/8/3q4/8/8/8/3RK3 w - - 0 1", 2, "d1d5", 0},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.positionFen.Parse()
+			info := NewSearch(src.MaterialEvaluator{}, NewTranspositionTable(1), nil).Run(context.Background(), position, tc.depth)
+			if assert.NotEmpty(t, info.PV) {
+				assert.Equal(t, tc.expectedBestMove, info.PV[0].UCI())
+			}
+			assert.Equal(t, tc.expectedMate, info.Mate)
+		})
+	}
+}
+
+func TestSearch_Report(t *testing.T) {
+	position, _ := src.Fen("r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3").Parse()
+
+	var infos []SearchInfo
+	info := NewSearch(src.MaterialEvaluator{}, NewTranspositionTable(1), func(info SearchInfo) {
+		infos = append(infos, info)
+	}).Run(context.Background(), position, 3)
+
+	assert.Len(t, infos, 3)
+	for i, report := range infos {
+		assert.Equal(t, i+1, report.Depth)
+		assert.Len(t, report.PV, report.Depth)
+		assert.Greater(t, report.Nodes, uint64(0))
+		if i > 0 {
+			assert.Greater(t, report.Nodes, infos[i-1].Nodes)
+		}
+	}
+	assert.Equal(t, infos[2], info)
+
+	// principal variation is a sequence of legal moves
+	for _, move := range info.PV {
+		assert.Contains(t, src.GenerateAllMoves(position, 0), move)
+		position.MakeMove(move)
+	}
+}
+
+func TestSearch_Stop(t *testing.T) {
+	position, _ := src.Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
+
+	t.Run("cancelled before the first iteration", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		info := NewSearch(src.MaterialEvaluator{}, NewTranspositionTable(1), nil).Run(ctx, position, 0)
+		assert.Equal(t, 0, info.Depth)
+		assert.Len(t, info.PV, 1)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		info := NewSearch(src.MaterialEvaluator{}, NewTranspositionTable(1), nil).Run(ctx, position, 0)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.GreaterOrEqual(t, info.Depth, 1)
+		assert.NotEmpty(t, info.PV)
+	})
+
+	t.Run("without a transposition table", func(t *testing.T) {
+		info := NewSearch(src.MaterialEvaluator{}, nil, nil).Run(context.Background(), position, 2)
+		assert.Equal(t, 2, info.Depth)
+		assert.NotEmpty(t, info.PV)
+	})
+
+	t.Run("no legal moves", func(t *testing.T) {
+		checkmated, _ := src.Fen("rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3").Parse()
+		info := NewSearch(src.MaterialEvaluator{}, NewTranspositionTable(1), nil).Run(context.Background(), checkmated, 0)
+		assert.Empty(t, info.PV)
+	})
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"unsafe"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+)
+
+// Bound of the score stored in a transposition table entry
+type bound uint8
+
+const (
+	noBound bound = iota
+	// search failed low, score is at most the stored score
+	upperBound
+	// search failed high, score is at least the stored score
+	lowerBound
+	exactBound
+)
+
+type ttEntry struct {
+	key   uint64
+	move  src.Move
+	score int16
+	depth uint8
+	bound bound
+	// age of the search which stored the entry
+	age uint8
+}
+
+// Fixed size hash table of searched positions keyed by their zobrist key
+type TranspositionTable struct {
+	entries []ttEntry
+	// number of entries - 1, number of entries is a power of two
+	mask uint64
+	// incremented for every search, entries of older searches get replaced first
+	age uint8
+}
+
+// Number of entries sampled to calculate hashfull
+const hashfullSample = 1000
+
+// Transposition table of the largest power of two entries which fit in sizeMB megabytes
+func NewTranspositionTable(sizeMB int) *TranspositionTable {
+	tt := &TranspositionTable{}
+	tt.Resize(sizeMB)
+	return tt
+}
+
+// Reallocates the table in sizeMB megabytes, all entries are cleared
+func (tt *TranspositionTable) Resize(sizeMB int) {
+	if sizeMB < 1 {
+		sizeMB = 1
+	}
+	entries := uint64(sizeMB) << 20 / uint64(unsafe.Sizeof(ttEntry{}))
+
+	size := uint64(1)
+	for size*2 <= entries {
+		size *= 2
+	}
+	tt.entries, tt.mask, tt.age = make([]ttEntry, size), size-1, 0
+}
+
+// Removes all entries
+func (tt *TranspositionTable) Clear() {
+	for i := range tt.entries {
+		tt.entries[i] = ttEntry{}
+	}
+	tt.age = 0
+}
+
+// Permille of the sampled entries which are stored by the current search
+func (tt *TranspositionTable) Hashfull() int {
+	sample := hashfullSample
+	if len(tt.entries) < sample {
+		sample = len(tt.entries)
+	}
+
+	used := 0
+	for _, entry := range tt.entries[:sample] {
+		if entry.bound != noBound && entry.age == tt.age {
+			used++
+		}
+	}
+	return used * 1000 / sample
+}
+
+// Marks the start of a new search, entries of previous searches become replaceable
+func (tt *TranspositionTable) nextAge() {
+	tt.age++
+}
+
+// Entry of the position with its mate score adjusted to the ply, false if the position is not stored
+func (tt *TranspositionTable) probe(key uint64, ply int) (ttEntry, bool) {
+	entry := tt.entries[key&tt.mask]
+	if entry.bound == noBound || entry.key != key {
+		return ttEntry{}, false
+	}
+	entry.score = int16(scoreFromTT(int(entry.score), ply))
+	return entry, true
+}
+
+// Stores the search result of the position unless the slot holds a deeper entry of the current search
+func (tt *TranspositionTable) store(key uint64, move src.Move, score, depth, ply int, b bound) {
+	slot := &tt.entries[key&tt.mask]
+	if slot.bound != noBound && slot.key != key && slot.age == tt.age && int(slot.depth) > depth {
+		return
+	}
+
+	// a result without a best move keeps the best move of the previous result of the position
+	if move == 0 && slot.key == key {
+		move = slot.move
+	}
+	*slot = ttEntry{
+		key:   key,
+		move:  move,
+		score: int16(scoreToTT(score, ply)),
+		depth: uint8(depth),
+		bound: b,
+		age:   tt.age,
+	}
+}
+
+// Mate scores are stored relative to the position instead of the root of the search
+func scoreToTT(score, ply int) int {
+	switch {
+	case score >= MateScore-MaxPly:
+		return score + ply
+	case score <= -MateScore+MaxPly:
+		return score - ply
+	default:
+		return score
+	}
+}
+
+func scoreFromTT(score, ply int) int {
+	switch {
+	case score >= MateScore-MaxPly:
+		return score - ply
+	case score <= -MateScore+MaxPly:
+		return score + ply
+	default:
+		return score
+	}
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bhavya5jain/go-django-unchained/src"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTranspositionTable(t *testing.T) {
+	// 16 byte entries
+	assert.Len(t, NewTranspositionTable(1).entries, 1<<16)
+	assert.Len(t, NewTranspositionTable(3).entries, 1<<17)
+	assert.Len(t, NewTranspositionTable(0).entries, 1<<16)
+
+	tt := NewTranspositionTable(1)
+	tt.Resize(4)
+	assert.Len(t, tt.entries, 1<<18)
+	assert.Equal(t, uint64(1<<18-1), tt.mask)
+}
+
+func TestTranspositionTable_Store(t *testing.T) {
+	// TTTC = Transposition Table Test Cases
+	type TTTC struct {
+		desc          string
+		key           uint64
+		depth         int
+		move          src.Move
+		newSearch     bool
+		expectedKey   uint64
+		expectedDepth uint8
+		expectedMove  src.Move
+	}
+
+	const key = 0x1234
+	// same slot as key
+	const otherKey = key + 1<<16
+	startingPosition, _ := src.Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
+	e2e4, _ := startingPosition.ParseUCIMove("e2e4")
+	d2d4, _ := startingPosition.ParseUCIMove("d2d4")
+
+	tcs := []TTTC{
+		{"same position shallower", key, 2, d2d4, false, key, 2, d2d4},
+		{"same position without move keeps the move", key, 2, 0, false, key, 2, e2e4},
+		{"other position shallower", otherKey, 2, d2d4, false, key, 5, e2e4},
+		{"other position as deep", otherKey, 5, d2d4, false, otherKey, 5, d2d4},
+		{"other position shallower in a new search", otherKey, 1, d2d4, true, otherKey, 1, d2d4},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tt := NewTranspositionTable(1)
+			tt.store(key, e2e4, 10, 5, 0, exactBound)
+			if tc.newSearch {
+				tt.nextAge()
+			}
+			tt.store(tc.key, tc.move, 20, tc.depth, 0, lowerBound)
+
+			entry, ok := tt.probe(tc.expectedKey, 0)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expectedDepth, entry.depth)
+			assert.Equal(t, tc.expectedMove, entry.move)
+		})
+	}
+}
+
+func TestTranspositionTable_MateScores(t *testing.T) {
+	tt := NewTranspositionTable(1)
+
+	// checkmating at ply 5 found at ply 3 is a mate in 2 plies from the stored position
+	tt.store(1, 0, MateScore-5, 4, 3, exactBound)
+	entry, _ := tt.probe(1, 1)
+	assert.Equal(t, int16(MateScore-3), entry.score)
+
+	tt.store(2, 0, -MateScore+6, 4, 2, exactBound)
+	entry, _ = tt.probe(2, 4)
+	assert.Equal(t, int16(-MateScore+8), entry.score)
+
+	tt.store(3, 0, 150, 4, 2, exactBound)
+	entry, _ = tt.probe(3, 7)
+	assert.Equal(t, int16(150), entry.score)
+}
+
+func TestTranspositionTable_Hashfull(t *testing.T) {
+	tt := NewTranspositionTable(1)
+	assert.Equal(t, 0, tt.Hashfull())
+
+	for key := uint64(0); key < hashfullSample/2; key++ {
+		tt.store(key, 0, 0, 1, 0, exactBound)
+	}
+	assert.Equal(t, 500, tt.Hashfull())
+
+	// entries of previous searches don't count
+	tt.nextAge()
+	assert.Equal(t, 0, tt.Hashfull())
+
+	tt.store(1, 0, 0, 1, 0, exactBound)
+	tt.Clear()
+	_, ok := tt.probe(1, 0)
+	assert.False(t, ok)
+}
+
+func TestSearch_TranspositionTable(t *testing.T) {
+	position, _ := src.Fen("r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 2 3").Parse()
+	search := NewSearch(src.MaterialEvaluator{}, NewTranspositionTable(1), nil)
+
+	first := search.Run(context.Background(), position, 4)
+	second := search.Run(context.Background(), position, 4)
+	assert.Less(t, second.Nodes, first.Nodes)
+	assert.Equal(t, first.Score, second.Score)
+}
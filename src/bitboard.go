@@ -7,13 +7,21 @@ import (
 	"strings"
 )
 
+// precalculating attack tables & square masks used by the move generator
+func init() {
+	GenerateSquareMasks()
+	GenerateFileRankMasks()
+	GenerateMagics()
+	GenerateNonSlidingPieceTypeAttackingSquares()
+}
+
 type Bitboard uint64
 
 type PieceBitboard [TotalPieceTypes]Bitboard
 
-type PiecePlacement map[Color]PieceBitboard
+type PiecePlacement [TotalColors]PieceBitboard
 
-type OccupiedSquaresColorWise map[Color]Bitboard
+type OccupiedSquaresColorWise [TotalColors]Bitboard
 
 func (bb Bitboard) leftmostSignificantSquare() Square {
 	return Square(Log2n(uint64(bb) & uint64(-bb)))
@@ -31,6 +39,10 @@ func (bb Bitboard) spawnMoves(initialSq Square, moveType Move) (moveList []Move)
 	return
 }
 
+func (bb Bitboard) countSquares() int {
+	return bits.OnesCount64(uint64(bb))
+}
+
 func (bb Bitboard) reverseBits() Bitboard {
 	return Bitboard(bits.Reverse64(uint64(bb)))
 }
@@ -86,6 +98,22 @@ func (bb Bitboard) shift(d Direction) Bitboard {
 	}
 }
 
+// Extends every set square till the eighth rank
+func (bb Bitboard) northFill() Bitboard {
+	bb |= bb << 8
+	bb |= bb << 16
+	bb |= bb << 32
+	return bb
+}
+
+// Extends every set square till the first rank
+func (bb Bitboard) southFill() Bitboard {
+	bb |= bb >> 8
+	bb |= bb >> 16
+	bb |= bb >> 32
+	return bb
+}
+
 func (bb Bitboard) bitShift(i Direction) Bitboard {
 	if i >= 0 {
 		return bb << i
@@ -101,6 +129,7 @@ const (
 	rank
 	diagonal
 	antiDiagonal
+	totalSliders
 )
 
 /*
@@ -109,13 +138,22 @@ const (
 */
 type SqMask struct {
 	bitMask      Bitboard
-	sliderMaskEx map[Slider]Bitboard
+	sliderMaskEx [totalSliders]Bitboard
 }
 
 var notAFile Bitboard = 0xfefefefefefefefe
 var notHFile Bitboard = 0x7f7f7f7f7f7f7f7f
 var notABFile Bitboard = 0xFCFCFCFCFCFCFCFC
 var notGHFile Bitboard = 0x3F3F3F3F3F3F3F3F
+var lightSquares Bitboard = 0x55AA55AA55AA55AA
+var darkSquares Bitboard = 0xAA55AA55AA55AA55
+
+// file & rank masks indexed from the a file & the first rank
+var fileMasks [8]Bitboard
+var rankMasks [8]Bitboard
+
+// masks of the files on both sides of the file
+var adjacentFileMasks [8]Bitboard
 
 /*
 	Calcuated in starting to generate sliding piece attacks
@@ -140,7 +178,6 @@ func GenerateSquareMasks() {
 
 	// file Mask excluding the square
 	for i := 0; i < 64; i++ {
-		sqMask[i].sliderMaskEx = make(map[Slider]Bitboard)
 		sqMask[i].sliderMaskEx[file] = (n << i) | (s >> (63 - i))
 	}
 
@@ -175,14 +212,27 @@ func GenerateSquareMasks() {
 	}
 }
 
+// precalculate file, rank & adjacent file masks
+func GenerateFileRankMasks() {
+	var aFile Bitboard = 0x0101010101010101
+	var firstRank Bitboard = 0xFF
+
+	for i := 0; i < 8; i++ {
+		fileMasks[i] = aFile << i
+		rankMasks[i] = firstRank << (8 * i)
+	}
+	for i := 0; i < 8; i++ {
+		adjacentFileMasks[i] = fileMasks[i].shift(east) | fileMasks[i].shift(west)
+	}
+}
+
 // Calculated at starting
 var KingAttacks [64]Bitboard
 var KnightAttacks [64]Bitboard
-var PawnAttacks map[Color][64]Bitboard
+var PawnAttacks [TotalColors][64]Bitboard
 
 func GenerateNonSlidingPieceTypeAttackingSquares() {
 	var WhitePawnAttacks, BlackPawnAttacks [64]Bitboard
-	PawnAttacks = make(map[Color][64]Bitboard)
 
 	for i := 0; i < 64; i++ {
 		// sqBb = square Bitboard
@@ -223,11 +273,11 @@ func (pt PieceType) attackBbSP(color Color, sq Square, ourSquares, opponentSquar
 	case King:
 		return KingAttacks[sq]
 	case Queen:
-		return diagonal.sliderAttacks(sq, allOcc) | antiDiagonal.sliderAttacks(sq, allOcc) | file.sliderAttacks(sq, allOcc) | rank.sliderAttacks(sq, allOcc)
+		return rookAttacks(sq, allOcc) | bishopAttacks(sq, allOcc)
 	case Rook:
-		return file.sliderAttacks(sq, allOcc) | rank.sliderAttacks(sq, allOcc)
+		return rookAttacks(sq, allOcc)
 	case Bishop:
-		return diagonal.sliderAttacks(sq, allOcc) | antiDiagonal.sliderAttacks(sq, allOcc)
+		return bishopAttacks(sq, allOcc)
 	case Knight:
 		return KnightAttacks[sq]
 	case Pawn:
@@ -262,6 +312,27 @@ func (slider Slider) sliderAttacks(sq Square, occ Bitboard) Bitboard {
 	return forward
 }
 
+// Rook attacks by Hyperbola Quintessence
+func hqRookAttacks(sq Square, occ Bitboard) Bitboard {
+	return file.sliderAttacks(sq, occ) | rank.sliderAttacks(sq, occ)
+}
+
+// Bishop attacks by Hyperbola Quintessence
+func hqBishopAttacks(sq Square, occ Bitboard) Bitboard {
+	return diagonal.sliderAttacks(sq, occ) | antiDiagonal.sliderAttacks(sq, occ)
+}
+
+// Squares strictly between two squares lying on the same file, rank or diagonal
+// 0 if squares are not aligned
+func squaresBetween(sq1, sq2 Square) Bitboard {
+	for slider := file; slider <= antiDiagonal; slider++ {
+		if sqMask[sq1].sliderMaskEx[slider]&sqMask[sq2].bitMask != 0 {
+			return slider.sliderAttacks(sq1, sqMask[sq2].bitMask) & slider.sliderAttacks(sq2, sqMask[sq1].bitMask)
+		}
+	}
+	return 0
+}
+
 func Log2n(n uint64) uint16 {
 	if n > 1 {
 		return 1 + Log2n(n/2)
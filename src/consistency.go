@@ -0,0 +1,40 @@
+package src
+
+import "fmt"
+
+// Checks that the information kept incrementally by the position agrees with its piece placement:
+// bitboards don't overlap, the mailbox, occupancies & zobrist keys match the bitboards
+func (position Position) checkConsistency() error {
+	var occupied Bitboard
+	for c := White; c < TotalColors; c++ {
+		var colorOccupied Bitboard
+		for pt := Pawn; pt < TotalPieceTypes; pt++ {
+			if overlap := occupied & position.piecePlacement[c][pt]; overlap != 0 {
+				return fmt.Errorf("%v %v overlaps other pieces on\n%v", c, pt, overlap)
+			}
+			occupied |= position.piecePlacement[c][pt]
+			colorOccupied |= position.piecePlacement[c][pt]
+		}
+		if colorOccupied != position.occupiedSquaresColorWise[c] {
+			return fmt.Errorf("%v occupancy\n%vdoesn't match pieces on\n%v", c, position.occupiedSquaresColorWise[c], colorOccupied)
+		}
+	}
+	if occupied != position.allOccupiedSquares {
+		return fmt.Errorf("occupancy\n%vdoesn't match pieces on\n%v", position.allOccupiedSquares, occupied)
+	}
+
+	mailbox := position.piecePlacement.mailbox()
+	for sq := Square(0); sq < 64; sq++ {
+		if position.mailbox[sq] != mailbox[sq] {
+			return fmt.Errorf("mailbox has %q on %s but bitboards have %q", position.mailbox[sq], sq, mailbox[sq])
+		}
+	}
+
+	if key := position.calculateZobristKey(); key != position.zobristKey {
+		return fmt.Errorf("zobrist key %016x doesn't match %016x", position.zobristKey, key)
+	}
+	if key := position.calculatePawnKey(); key != position.pawnKey {
+		return fmt.Errorf("pawn key %016x doesn't match %016x", position.pawnKey, key)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package src
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConsistency(t *testing.T) {
+	position, _ := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
+	assert.NoError(t, position.checkConsistency())
+
+	corrupted := position
+	corrupted.mailbox[E4] = NewPiece(White, Queen)
+	assert.EqualError(t, corrupted.checkConsistency(), `mailbox has "Q" on e4 but bitboards have ""`)
+
+	corrupted = position
+	corrupted.piecePlacement[Black][Knight] |= sqMask[E2].bitMask
+	assert.Error(t, corrupted.checkConsistency())
+
+	corrupted = position
+	corrupted.zobristKey ^= 1
+	assert.Error(t, corrupted.checkConsistency())
+}
+
+// Plays random moves on the position & checks its consistency after every make & unmake
+// Errors are reported without FailNow, so it can run on other goroutines
+func playRandomGame(t *testing.T, position Position, random *rand.Rand, moves int) {
+	initialFen := position.Fen()
+
+	var undos []Undo
+	for i := 0; i < moves; i++ {
+		legalMoves := GenerateAllMoves(position, 0)
+		if len(legalMoves) == 0 {
+			break
+		}
+		undos = append(undos, position.MakeMove(legalMoves[random.Intn(len(legalMoves))]))
+		if err := position.checkConsistency(); err != nil {
+			t.Errorf("after %v: %v", undos[len(undos)-1].move, err)
+			return
+		}
+	}
+
+	for i := len(undos) - 1; i >= 0; i-- {
+		position.UnmakeMove(undos[i])
+		if err := position.checkConsistency(); err != nil {
+			t.Errorf("after unmaking %v: %v", undos[i].move, err)
+			return
+		}
+	}
+	assert.Equal(t, initialFen, position.Fen())
+}
+
+// Copies of a position are played concurrently, run with -race to check that copies share no state
+func TestConsistency_ConcurrentGames(t *testing.T) {
+	for _, fen := range fenTestSuite {
+		position, _ := fen.Parse()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(seed int64) {
+				defer wg.Done()
+				playRandomGame(t, position, rand.New(rand.NewSource(seed)), 50)
+			}(int64(i))
+		}
+		wg.Wait()
+		assert.Equal(t, fen, position.Fen())
+	}
+}
+
+func FuzzMakeUnmakeMove(f *testing.F) {
+	for i, fen := range fenTestSuite {
+		f.Add(string(fen), int64(i))
+	}
+
+	f.Fuzz(func(t *testing.T, fen string, seed int64) {
+		position, err := Fen(fen).Parse()
+		if err != nil {
+			return
+		}
+		if err := position.checkConsistency(); err != nil {
+			t.Fatalf("after parsing: %v", err)
+		}
+		playRandomGame(t, position, rand.New(rand.NewSource(seed)), 100)
+	})
+}
@@ -0,0 +1,121 @@
+package src
+
+// Values of the piece types in centipawns
+var pieceValues = [TotalPieceTypes]int{
+	Pawn:   100,
+	Knight: 320,
+	Bishop: 330,
+	Rook:   500,
+	Queen:  900,
+}
+
+// Value of the piece type in centipawns, 0 for the king
+func PieceValue(pt PieceType) int {
+	return pieceValues[pt]
+}
+
+// Evaluates the position by the material only
+type MaterialEvaluator struct{}
+
+func (MaterialEvaluator) Evaluate(position Position) int {
+	score := 0
+	for pt := Pawn; pt < King; pt++ {
+		ours, theirs := position.piecePlacement[position.activeColor][pt], position.piecePlacement[position.activeColor.Opponent()][pt]
+		score += pieceValues[pt] * (ours.countSquares() - theirs.countSquares())
+	}
+	return score
+}
+
+// Weights of the piece types in the game phase, the phase is totalPhase with all pieces on the board & 0 with only pawns
+var phaseWeights = [TotalPieceTypes]int{
+	Knight: 1,
+	Bishop: 1,
+	Rook:   2,
+	Queen:  4,
+}
+
+const totalPhase = 24
+
+// Bonuses in centipawns for the midgame (mg) & the endgame (eg)
+const (
+	bishopPairMg       = 30
+	bishopPairEg       = 50
+	rookOpenFileMg     = 25
+	rookOpenFileEg     = 10
+	rookSemiOpenFileMg = 12
+	rookSemiOpenFileEg = 6
+)
+
+// Evaluates the position by material & piece square tables tapered between the midgame & the endgame,
+// bishop pair, rooks on open files & pawn structure
+type Evaluator struct {
+	pawnTable *pawnHashTable
+}
+
+func NewEvaluator() *Evaluator {
+	return &Evaluator{pawnTable: &pawnHashTable{}}
+}
+
+func (e *Evaluator) Evaluate(position Position) int {
+	score := e.evaluateWhite(position)
+	if position.activeColor == Black {
+		return -score
+	}
+	return score
+}
+
+// Score of the position from the point of view of white
+func (e *Evaluator) evaluateWhite(position Position) int {
+	whiteMg, whiteEg, whitePhase := evaluateSide(position, White)
+	blackMg, blackEg, blackPhase := evaluateSide(position, Black)
+
+	pawns := e.pawnTable.probe(position)
+	whitePassedMg, whitePassedEg := evaluatePassedPawns(position, White, pawns.whitePassed)
+	blackPassedMg, blackPassedEg := evaluatePassedPawns(position, Black, pawns.blackPassed)
+	whiteMg, whiteEg = whiteMg+pawns.mg+whitePassedMg, whiteEg+pawns.eg+whitePassedEg
+	blackMg, blackEg = blackMg+blackPassedMg, blackEg+blackPassedEg
+
+	// promotions can take the phase over totalPhase
+	phase := whitePhase + blackPhase
+	if phase > totalPhase {
+		phase = totalPhase
+	}
+	mg, eg := whiteMg-blackMg, whiteEg-blackEg
+	return (mg*phase + eg*(totalPhase-phase)) / totalPhase
+}
+
+// Midgame & endgame scores of the pieces of the color along with their weight in the game phase
+func evaluateSide(position Position, c Color) (mg, eg, phase int) {
+	pieces := position.piecePlacement[c]
+	opponentPawns := position.piecePlacement[c.Opponent()][Pawn]
+
+	for pt := Pawn; pt < TotalPieceTypes; pt++ {
+		for bb := pieces[pt]; bb > 0; bb &= bb - 1 {
+			sq := bb.leftmostSignificantSquare()
+			// tables are laid out from a8, black uses the mirrored square
+			i := sq
+			if c == White {
+				i = sq.Mirror()
+			}
+
+			mg += pieceValues[pt] + mgTables[pt][i]
+			eg += pieceValues[pt] + egTables[pt][i]
+			phase += phaseWeights[pt]
+
+			if pt == Rook {
+				if fileMasks[sq.File()]&pieces[Pawn] == 0 {
+					if fileMasks[sq.File()]&opponentPawns == 0 {
+						mg, eg = mg+rookOpenFileMg, eg+rookOpenFileEg
+					} else {
+						mg, eg = mg+rookSemiOpenFileMg, eg+rookSemiOpenFileEg
+					}
+				}
+			}
+		}
+	}
+
+	if pieces[Bishop]&lightSquares != 0 && pieces[Bishop]&darkSquares != 0 {
+		mg, eg = mg+bishopPairMg, eg+bishopPairEg
+	}
+	return
+}
@@ -0,0 +1,105 @@
+package src
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fen of the position with the colors swapped & the board mirrored vertically
+func flipFen(fen Fen) Fen {
+	fields := strings.Fields(string(fen))
+
+	ranks := strings.Split(fields[0], "/")
+	for i, j := 0, len(ranks)-1; i < j; i, j = i+1, j-1 {
+		ranks[i], ranks[j] = ranks[j], ranks[i]
+	}
+	fields[0] = swapCase(strings.Join(ranks, "/"))
+
+	if fields[1] == "w" {
+		fields[1] = "b"
+	} else {
+		fields[1] = "w"
+	}
+
+	if fields[2] != "-" {
+		castling := swapCase(fields[2])
+		fields[2] = ""
+		for _, r := range "KQkq" {
+			if strings.ContainsRune(castling, r) {
+				fields[2] += string(r)
+			}
+		}
+	}
+
+	if fields[3] != "-" {
+		fields[3] = fields[3][:1] + string(rune('1'+'8'-fields[3][1]))
+	}
+
+	return Fen(strings.Join(fields, " "))
+}
+
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return r
+	}, s)
+}
+
+func TestFlipFen(t *testing.T) {
+	assert.Equal(t,
+		Fen("rnbqkbnr/ppp1pppp/8/3p4/8/8/PPPPPPPP/RNBQKBNR w Kq d6 0 2"),
+		flipFen("rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b Qk d3 0 2"))
+}
+
+func TestEvaluator_Symmetry(t *testing.T) {
+	for _, fen := range fenTestSuite {
+		t.Run(string(fen), func(t *testing.T) {
+			position, err := fen.Parse()
+			assert.NoError(t, err)
+			flipped, err := flipFen(fen).Parse()
+			assert.NoError(t, err)
+
+			evaluator := NewEvaluator()
+			assert.Equal(t, -evaluator.evaluateWhite(position), evaluator.evaluateWhite(flipped))
+			assert.Equal(t, evaluator.Evaluate(position), evaluator.Evaluate(flipped))
+		})
+	}
+}
+
+func TestEvaluator(t *testing.T) {
+	// ETC = Evaluator Test Cases
+	type ETC struct {
+		desc   string
+		better Fen
+		worse  Fen
+	}
+
+	tcs := []ETC{
+		{"bishop pair", "2b1kb2/8/8/8/8/8/8/2B1KB2 w - - 0 1", "2b1kb2/8/8/8/8/8/8/2N1KB2 w - - 0 1"},
+		{"rook on open file", "4k3/pp4pp/8/8/8/8/PP4PP/3RK3 w - - 0 1", "4k3/pp1p2pp/8/8/8/8/PP1P2PP/3RK3 w - - 0 1"},
+		{"rook on semi-open file", "4k3/pp1p2pp/8/8/8/8/PP4PP/3RK3 w - - 0 1", "4k3/pp1p2pp/8/8/8/8/PP4PP/R3K3 w - - 0 1"},
+		{"centralized knight", "4k3/8/8/8/3N4/8/8/4K3 w - - 0 1", "4k3/8/8/8/8/8/8/N3K3 w - - 0 1"},
+		{"castled king in the midgame", "rnbq1rk1/pppppppp/8/8/8/8/PPPPPPPP/RNBQ1RK1 w - - 0 1", "rnbq1rk1/pppppppp/8/8/8/4K3/PPPPPPPP/RNBQ1R2 w - - 0 1"},
+		{"centralized king in the endgame", "6k1/pp6/8/8/4K3/8/PP6/8 w - - 0 1", "6k1/pp6/8/8/8/8/PP6/6K1 w - - 0 1"},
+		{"advanced pawn in the endgame", "6k1/1P6/8/8/8/8/8/6K1 w - - 0 1", "6k1/8/8/8/8/8/1P6/6K1 w - - 0 1"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			better, _ := tc.better.Parse()
+			worse, _ := tc.worse.Parse()
+			evaluator := NewEvaluator()
+			assert.Greater(t, evaluator.Evaluate(better), evaluator.Evaluate(worse))
+		})
+	}
+
+	starting, _ := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
+	assert.Equal(t, 0, NewEvaluator().Evaluate(starting))
+}
@@ -1,7 +1,6 @@
 package src
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,65 +8,146 @@ import (
 
 type Fen string
 
+// Indices of the fen components
+const (
+	FenPiecePlacement = iota
+	FenActiveColor
+	FenCastlingRights
+	FenEnPassantTarget
+	FenHalfMoveClock
+	FenFullMoveNumber
+)
+
+var fenFieldNames = [6]string{
+	"piece placement",
+	"active color",
+	"castling rights",
+	"en passant target",
+	"half move clock",
+	"full move number",
+}
+
+// Error in parsing fen along with the position of the invalid character
+type FenError struct {
+	Field  int // index of the fen component, -1 if number of components is invalid
+	Offset int // offset of the invalid character in the fen string
+	Msg    string
+}
+
+func (fe *FenError) Error() string {
+	if fe.Field < 0 || fe.Field >= len(fenFieldNames) {
+		return fmt.Sprintf("fen char %d: %s", fe.Offset, fe.Msg)
+	}
+	return fmt.Sprintf("fen %s, char %d: %s", fenFieldNames[fe.Field], fe.Offset, fe.Msg)
+}
+
+// offset is relative to the start of the fen component, it is made absolute by Parse
+func newFenError(field, offset int, format string, a ...interface{}) *FenError {
+	return &FenError{
+		Field:  field,
+		Offset: offset,
+		Msg:    fmt.Sprintf(format, a...),
+	}
+}
+
+// Makes the offset of the error relative to the start of the fen string
+func (fe *FenError) inFen(fenSplit []string) *FenError {
+	for i := 0; i < fe.Field; i++ {
+		fe.Offset += len(fenSplit[i]) + 1
+	}
+	return fe
+}
+
+// Parses fen & rejects malformed components, wrong number of kings,
+// pawns on the first or last rank, castling rights & en passant target
+// without their pieces and the side not to move being in check
 func (fen Fen) Parse() (Position, error) {
 	fenSplit := strings.Split(string(fen), " ")
 
 	// fen string should contain 6 components
 	if len(fenSplit) != 6 {
-		return Position{}, errors.New("number of components in fen != 6")
+		return Position{}, newFenError(-1, 0, "number of components in fen %d != 6", len(fenSplit))
 	}
 
-	piecePlacement, err := parsePiecePlacement(fenSplit[0])
+	fieldError := func(err *FenError) error {
+		return err.inFen(fenSplit)
+	}
+
+	piecePlacement, err := parsePiecePlacement(fenSplit[FenPiecePlacement])
 	if err != nil {
-		return Position{}, err
+		return Position{}, fieldError(err)
 	}
 
-	activeColor, err := parseActiveColor(fenSplit[1])
+	activeColor, err := parseActiveColor(fenSplit[FenActiveColor])
 	if err != nil {
-		return Position{}, err
+		return Position{}, fieldError(err)
 	}
 
-	castlingRights, err := parseCastlingRights(fenSplit[2])
+	castlingRights, err := parseCastlingRights(fenSplit[FenCastlingRights])
 	if err != nil {
-		return Position{}, err
+		return Position{}, fieldError(err)
 	}
 
-	enPassantTarget, err := parseEnPassantTarget(fenSplit[3])
+	enPassantTarget, err := parseEnPassantTarget(fenSplit[FenEnPassantTarget], activeColor)
 	if err != nil {
-		return Position{}, err
+		return Position{}, fieldError(err)
 	}
 
-	halfMoveClock, err := strconv.Atoi(fenSplit[4])
+	halfMoveClock, err := parseMoveCounter(fenSplit[FenHalfMoveClock], FenHalfMoveClock, 0)
 	if err != nil {
-		return Position{}, err
+		return Position{}, fieldError(err)
 	}
 
-	fullMoveNumber, err := strconv.Atoi(fenSplit[5])
+	fullMoveNumber, err := parseMoveCounter(fenSplit[FenFullMoveNumber], FenFullMoveNumber, 1)
 	if err != nil {
-		return Position{}, err
+		return Position{}, fieldError(err)
 	}
 
-	return Position{
+	position := Position{
 		piecePlacement:  piecePlacement,
 		activeColor:     activeColor,
 		castlingRights:  castlingRights,
 		enPassantTarget: enPassantTarget,
-		halfMoveClock:   uint16(halfMoveClock),
-		fullMoveNumber:  uint16(fullMoveNumber),
-	}.generateAuxiliaryInfo(), nil
+		halfMoveClock:   halfMoveClock,
+		fullMoveNumber:  fullMoveNumber,
+	}.generateAuxiliaryInfo()
+	position.mailbox = piecePlacement.mailbox()
+	position.zobristKey = position.calculateZobristKey()
+	position.pawnKey = position.calculatePawnKey()
+
+	// moves can't be made on castling rights or an en passant target without their pieces
+	if err := validateCastlingRights(position, fenSplit); err != nil {
+		return Position{}, fieldError(err)
+	}
+	if err := validateEnPassantTarget(position, fenSplit); err != nil {
+		return Position{}, fieldError(err)
+	}
+
+	// side which is not to move can't be in check
+	if len(position.opponentKingCheckers()) > 0 {
+		return Position{}, fieldError(newFenError(FenActiveColor, 0, "%v to move but %v is in check", activeColor, activeColor.Opponent()))
+	}
+
+	return position, nil
 }
 
-func parsePiecePlacement(pp string) (PiecePlacement, error) {
+func parsePiecePlacement(pp string) (PiecePlacement, *FenError) {
 	ranks := strings.Split(pp, "/")
 	// return error if number of ranks != 8
 	if len(ranks) != 8 {
-		return nil, errors.New("number of ranks in fen !=8")
+		return PiecePlacement{}, newFenError(FenPiecePlacement, 0, "number of ranks in fen %d != 8", len(ranks))
 	}
 
 	var whitePieces, blackPieces PieceBitboard
-	for i := 0; i < 8; i++ {
+	// offset of the current character in piece placement
+	offset := 0
+	for i := 0; i < 8; i, offset = i+1, offset+1 {
 		k := 0
-		for j := 0; j < len(ranks[i]); j++ {
+		for j := 0; j < len(ranks[i]); j, offset = j+1, offset+1 {
+			if k >= 8 {
+				return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "rank %d has more than 8 files", 8-i)
+			}
+
 			index := 8*(7-i) + k
 			switch ranks[i][j] {
 			case 'P':
@@ -86,6 +166,9 @@ func parsePiecePlacement(pp string) (PiecePlacement, error) {
 				whitePieces[Queen] += 1 << index
 				k++
 			case 'K':
+				if whitePieces[King] != 0 {
+					return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "more than one White king")
+				}
 				whitePieces[King] += 1 << index
 				k++
 			case 'p':
@@ -104,40 +187,87 @@ func parsePiecePlacement(pp string) (PiecePlacement, error) {
 				blackPieces[Queen] += 1 << index
 				k++
 			case 'k':
+				if blackPieces[King] != 0 {
+					return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "more than one Black king")
+				}
 				blackPieces[King] += 1 << index
 				k++
 			default:
 				emptyPositions, err := strconv.ParseInt(string(ranks[i][j]), 10, 32)
 				if emptyPositions < 1 || emptyPositions > 8 || err != nil {
-					return nil, fmt.Errorf("char %s: invalid empty space", string(ranks[i][j]))
+					return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "char %s: invalid empty space", string(ranks[i][j]))
+				}
+				// consecutive empty spaces should be merged
+				if j > 0 && ranks[i][j-1] >= '1' && ranks[i][j-1] <= '8' {
+					return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "char %s: consecutive empty spaces", string(ranks[i][j]))
+				}
+				if k+int(emptyPositions) > 8 {
+					return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "rank %d has more than 8 files", 8-i)
 				}
 				k += int(emptyPositions)
 			}
+
+			// pawns can't be on first or last rank
+			if (i == 0 || i == 7) && (ranks[i][j] == 'P' || ranks[i][j] == 'p') {
+				return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "pawn on rank %d", 8-i)
+			}
+		}
+
+		if k < 8 {
+			return PiecePlacement{}, newFenError(FenPiecePlacement, offset, "rank %d has less than 8 files", 8-i)
 		}
 	}
 
-	// Piece Placement map
+	if whitePieces[King] == 0 {
+		return PiecePlacement{}, newFenError(FenPiecePlacement, 0, "no White king")
+	}
+	if blackPieces[King] == 0 {
+		return PiecePlacement{}, newFenError(FenPiecePlacement, 0, "no Black king")
+	}
+
 	return PiecePlacement{
 		White: whitePieces,
 		Black: blackPieces,
 	}, nil
 }
 
-func parseActiveColor(ac string) (Color, error) {
+func parseActiveColor(ac string) (Color, *FenError) {
 	switch ac {
 	case "w":
 		return White, nil
 	case "b":
 		return Black, nil
 	default:
-		return false, errors.New("active color invalid")
+		return 0, newFenError(FenActiveColor, 0, "active color %q invalid", ac)
 	}
 }
 
-func parseCastlingRights(cr string) (CastlingRights, error) {
+func parseCastlingRights(cr string) (CastlingRights, *FenError) {
 	blackCastlingType, WhiteCastlingType := CastlingType{}, CastlingType{}
 
+	if cr == "-" {
+		return CastlingRights{
+			White: CastlingType{},
+			Black: CastlingType{},
+		}, nil
+	}
+	if cr == "" {
+		return CastlingRights{}, newFenError(FenCastlingRights, 0, "castling rights empty")
+	}
+
+	// castling rights should appear at most once in KQkq order
+	order := "KQkq"
+	last := -1
 	for i := 0; i < len(cr); i++ {
+		current := strings.IndexByte(order, cr[i])
+		if current < 0 {
+			return CastlingRights{}, newFenError(FenCastlingRights, i, "char %s: castling rights invalid format", string(cr[i]))
+		}
+		if current <= last {
+			return CastlingRights{}, newFenError(FenCastlingRights, i, "char %s: castling rights repeated or not in KQkq order", string(cr[i]))
+		}
+		last = current
+
 		switch cr[i] {
 		case 'K':
 			WhiteCastlingType.kingSide = true
@@ -147,10 +277,6 @@ func parseCastlingRights(cr string) (CastlingRights, error) {
 			blackCastlingType.kingSide = true
 		case 'q':
 			blackCastlingType.queenSide = true
-		case '-':
-			return make(CastlingRights), nil
-		default:
-			return nil, errors.New("castling rights invalid format")
 		}
 	}
 	return CastlingRights{
@@ -159,19 +285,112 @@ func parseCastlingRights(cr string) (CastlingRights, error) {
 	}, nil
 }
 
-func parseEnPassantTarget(ept string) (Square, error) {
-	enPassantTargetSquare := rune(64)
-	chars := []rune(ept)
-
-	// checking if move is in right format
-	if len(chars) == 2 && chars[0] <= 104 && chars[0] >= 97 && chars[1] <= 56 && chars[1] >= 49 {
-		file := chars[0] - 97
-		rank := chars[1] - 49
-		enPassantTargetSquare = rank*8 + file
-		return Square(enPassantTargetSquare), nil
-	} else if ept == "-" {
+func parseEnPassantTarget(ept string, activeColor Color) (Square, *FenError) {
+	if ept == "-" {
 		return 64, nil
-	} else {
-		return 0, errors.New("en passant target in wrong format")
 	}
+
+	sq, err := ParseSquare(ept)
+	if err != nil {
+		return 0, newFenError(FenEnPassantTarget, 0, "en passant target %q in wrong format", ept)
+	}
+
+	// en passant target is behind the pawn which was just pushed 2 squares by the side not to move
+	if (activeColor == White && sq.Rank() != 5) || (activeColor == Black && sq.Rank() != 2) {
+		return 0, newFenError(FenEnPassantTarget, 1, "en passant target %s on wrong rank for %v to move", ept, activeColor)
+	}
+	return sq, nil
+}
+
+// Parses half move clock or full move number which should be at least min
+func parseMoveCounter(mc string, field int, min uint64) (uint16, *FenError) {
+	for i := 0; i < len(mc); i++ {
+		if mc[i] < '0' || mc[i] > '9' {
+			return 0, newFenError(field, i, "char %s: %s should be a number", string(mc[i]), fenFieldNames[field])
+		}
+	}
+
+	counter, err := strconv.ParseUint(mc, 10, 16)
+	if err != nil {
+		return 0, newFenError(field, 0, "%s %q invalid", fenFieldNames[field], mc)
+	}
+	if counter < min {
+		return 0, newFenError(field, 0, "%s %d should be at least %d", fenFieldNames[field], counter, min)
+	}
+	return uint16(counter), nil
+}
+
+// Serializes the position into all 6 components of fen
+func (position Position) Fen() Fen {
+	fenSplit := []string{
+		position.piecePlacement.fen(),
+		"w",
+		position.castlingRights.fen(),
+		"-",
+		strconv.Itoa(int(position.halfMoveClock)),
+		strconv.Itoa(int(position.fullMoveNumber)),
+	}
+
+	if position.activeColor == Black {
+		fenSplit[1] = "b"
+	}
+	if position.enPassantTarget < 64 {
+		fenSplit[3] = position.enPassantTarget.String()
+	}
+
+	return Fen(strings.Join(fenSplit, " "))
+}
+
+func (pp PiecePlacement) fen() string {
+	var ranks []string
+	for r := 7; r >= 0; r-- {
+		rankRep := ""
+		emptyPositions := 0
+		for f := 0; f < 8; f++ {
+			sqBb := Bitboard(1 << (8*r + f))
+			pieceRep := ""
+			for pt := Pawn; pt < TotalPieceTypes; pt++ {
+				if pp[White][pt]&sqBb != 0 {
+					pieceRep = pt.fenRep(White)
+				} else if pp[Black][pt]&sqBb != 0 {
+					pieceRep = pt.fenRep(Black)
+				}
+			}
+
+			if pieceRep == "" {
+				emptyPositions++
+				continue
+			}
+			if emptyPositions > 0 {
+				rankRep += strconv.Itoa(emptyPositions)
+				emptyPositions = 0
+			}
+			rankRep += pieceRep
+		}
+		if emptyPositions > 0 {
+			rankRep += strconv.Itoa(emptyPositions)
+		}
+		ranks = append(ranks, rankRep)
+	}
+	return strings.Join(ranks, "/")
+}
+
+func (cr CastlingRights) fen() string {
+	crRep := ""
+	if cr[White].kingSide {
+		crRep += "K"
+	}
+	if cr[White].queenSide {
+		crRep += "Q"
+	}
+	if cr[Black].kingSide {
+		crRep += "k"
+	}
+	if cr[Black].queenSide {
+		crRep += "q"
+	}
+	if crRep == "" {
+		return "-"
+	}
+	return crRep
 }
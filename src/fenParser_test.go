@@ -1 +1,173 @@
 package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fens used across the test suites
+var fenTestSuite = []Fen{
+	"2K1R3/R2P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1",
+	"2KQR3/R5k1/8/p7/8/3n2q1/1P6/6r1 b - - 0 1",
+	"2R1b2k/pP1PP1p1/4K3/PpPp1Pq1/4PppP/5n2/P1p3p1/3Q1b1R b - h3 0 1",
+	"2R1b2k/pP1PP1p1/4K3/PpPp1Pq1/4PppP/5n2/P1p3p1/3Q1b1R w - d6 0 1",
+	"2kr3r/8/8/8/8/8/8/R3K2R w KQ - 4 11",
+	"2r5/2r2ppp/5k2/B1NBb3/2R5/6P1/P4P1P/3R2K1 b - - 2 35",
+	"2r5/2r2ppp/5k2/B1nBb3/2R5/6P1/P4P1P/3R2K1 b - - 2 35",
+	"2r5/2r2ppp/5k2/B1nBb3/2R5/6P1/P4P1P/3R2K1 w - - 2 35",
+	"3rk3/8/8/8/8/8/8/R3K2R w KQ - 0 1",
+	"4B3/RK4k1/8/p7/8/3n2q1/1P6/6r1 b - - 0 1",
+	"4k3/4b3/8/8/8/8/4N3/4K3 w - - 0 1",
+	"4k3/4r3/8/4p3/8/8/4N3/4K3 w - - 0 1",
+	"4k3/4r3/8/8/4P3/8/4N3/4K3 w - - 0 1",
+	"4k3/4r3/8/8/8/2N5/8/4K3 w - - 0 1",
+	"4k3/4r3/8/8/8/8/4N3/4K3 w - - 0 1",
+	"4k3/4r3/8/8/8/8/8/4K3 w - - 0 1",
+	"4k3/6N1/5b2/4R3/8/8/8/4K3 b - - 0 1",
+	"4k3/8/5N2/8/8/8/8/4K3 b - - 0 1",
+	"4k3/8/8/4q3/8/8/8/4K3 w - - 0 1",
+	"4k3/8/8/5R2/8/8/8/4K3 b - - 0 1",
+	"4k3/8/8/8/1b6/8/8/4K3 w - - 0 1",
+	"4k3/8/8/8/8/3n4/8/4K3 w - - 0 1",
+	"4k3/8/8/8/8/3n4/8/r3K1B1 w - - 0 1",
+	"4k3/8/8/8/8/5b2/4P3/3K4 w - - 0 1",
+	"4k3/8/8/8/8/6q1/8/4K3 w - - 0 1",
+	"4k3/8/8/8/8/8/3p4/4K3 w - - 0 1",
+	"4k3/8/8/8/8/8/4q3/4K3 w - - 0 1",
+	"4k3/8/8/8/8/8/8/r3K3 w - - 0 1",
+	"4k3/8/8/q7/8/8/3B4/4K3 w - - 0 1",
+	"4r3/RK1P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1",
+	"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+	"8/4k3/8/4R3/8/8/8/4K3 b - - 0 1",
+	"8/8/8/2k5/3Pp3/8/8/4K3 b - d3 0 1",
+	"8/8/8/K2pP2r/8/8/8/4k3 w - d6 0 1",
+	"Q7/5p1k/7p/P7/4K3/8/8/3q4 w - - 7 68",
+	"R3k2r/8/8/8/8/8/8/4K2R b Kk - 0 1",
+	"r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1",
+	"r3k1br/8/8/8/8/8/8/R1qBK2R b KQkq - 0 1",
+	"r3k1br/8/8/8/8/8/8/R1q1K2R w KQkq - 0 1",
+	"r3k2r/8/8/8/8/8/8/2KR3R b kq - 4 10",
+	"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1",
+	"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 3 10",
+	"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+	"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 3 10",
+	"r3k2r/8/8/8/8/8/8/R4RK1 b kq - 4 10",
+	"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+	"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+	"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+	"r4rk1/8/8/8/8/8/8/R3K2R w KQ - 4 11",
+	"r6r/3k4/8/8/8/8/8/R3K2R w KQ - 1 2",
+	"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+	"rnbqkb1r/pppppppp/5n2/8/8/5N2/PPPPPPPP/RNBQKB1R w KQkq - 2 2",
+	"rnbqkbnr/ppp1p1pp/5P2/3p4/8/8/PPPP1PPP/RNBQKBNR b KQkq - 0 3",
+	"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3",
+	"rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 3",
+	"rnbqkbnr/ppp1pppp/8/8/8/4p3/PPPP1PPP/RNBQKBNR w KQkq - 0 4",
+	"rnbqkbnr/pppp1ppp/8/4P3/8/8/PPP1PPPP/RNBQKBNR b KQkq - 0 2",
+	"rnbqkbnr/pppp1ppp/8/4p3/3P4/8/PPP1PPPP/RNBQKBNR w KQkq e6 0 2",
+	"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq d3 0 1",
+	"rnbqkbnr/pppppppp/8/8/8/5N2/PPPPPPPP/RNBQKB1R b KQkq - 1 1",
+	"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+}
+
+func TestFen(t *testing.T) {
+	for _, fen := range fenTestSuite {
+		t.Run(string(fen), func(t *testing.T) {
+			position, err := fen.Parse()
+			assert.NoError(t, err)
+			assert.Equal(t, fen, position.Fen())
+		})
+	}
+}
+
+func TestFen_AfterMakeMove(t *testing.T) {
+	for _, tc := range updatePositionTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.initialPositionFen.Parse()
+			position.MakeMove(tc.move)
+			assert.Equal(t, tc.expectedFinalPositionFen, position.Fen())
+		})
+	}
+}
+
+// FETC = Fen Error Test Cases
+type FETC struct {
+	desc           string
+	fen            Fen
+	expectedField  int
+	expectedOffset int
+}
+
+func TestFenParse_Errors(t *testing.T) {
+	tcs := []FETC{
+		{"number of components", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0", -1, 0},
+		{"rank overflow by piece", "rnbqkbnrp/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FenPiecePlacement, 8},
+		{"rank overflow by empty spaces", "rnbqkbnr/pppppppp/8/8/4P4/8/PPPP1PPP/RNBQKBNR w KQkq - 0 1", FenPiecePlacement, 24},
+		{"rank underflow", "rnbqkbnr/pppppppp/8/8/7/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FenPiecePlacement, 23},
+		{"consecutive empty spaces", "rnbqkbnr/pppppppp/8/8/44/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FenPiecePlacement, 23},
+		{"two white kings", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKKNR w KQkq - 0 1", FenPiecePlacement, 40},
+		{"no black king", "rnbq1bnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQ - 0 1", FenPiecePlacement, 0},
+		{"pawn on last rank", "rnbqkbnp/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQq - 0 1", FenPiecePlacement, 7},
+		{"invalid active color", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1", FenActiveColor, 44},
+		{"side not to move in check", "4k3/8/8/8/8/8/8/4R1K1 w - - 0 1", FenActiveColor, 22},
+		{"castling rights repeated", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KKkq - 0 1", FenCastlingRights, 47},
+		{"castling rights out of order", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w kK - 0 1", FenCastlingRights, 47},
+		{"castling rights invalid char", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQx - 0 1", FenCastlingRights, 48},
+		{"en passant target on wrong rank", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e4 0 1", FenEnPassantTarget, 54},
+		{"en passant target wrong format", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e 0 1", FenEnPassantTarget, 51},
+		{"castling without rook", "rnbqkbn1/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FenCastlingRights, 48},
+		{"castling without king on its home square", "4k3/8/8/8/8/8/8/3K3R w K - 0 1", FenCastlingRights, 23},
+		{"en passant target without pushed pawn", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq e3 0 1", FenEnPassantTarget, 51},
+		{"en passant target occupied", "4k3/8/4n3/3Pp3/8/8/8/4K3 w - e6 0 1", FenEnPassantTarget, 29},
+		{"negative half move clock", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - -1 1", FenHalfMoveClock, 53},
+		{"zero full move number", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 0", FenFullMoveNumber, 55},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.fen.Parse()
+			var fenError *FenError
+			if assert.ErrorAs(t, err, &fenError) {
+				assert.Equal(t, tc.expectedField, fenError.Field, fenError.Error())
+				assert.Equal(t, tc.expectedOffset, fenError.Offset, fenError.Error())
+			}
+		})
+	}
+}
+
+func TestFenParseStrict(t *testing.T) {
+	tcs := []FETC{
+		{"en passant target pushed from occupied square", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPPPPP1/RNBQKBNR b KQkq e3 0 1", FenEnPassantTarget, 53},
+		{"half move clock after double pawn push", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 3 1", FenHalfMoveClock, 56},
+		{"promoted piece with 8 pawns", "rnbqkbnr/pppppppp/8/8/8/3Q4/PPPPPPPP/RNBQKBNR w KQkq - 0 1", FenPiecePlacement, 0},
+		{"checked by two knights", "4k3/8/3N1N2/8/8/8/8/4K3 b - - 0 1", FenActiveColor, 24},
+		{"half move clock more than moves played", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 5 1", FenHalfMoveClock, 53},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.fen.Parse()
+			assert.NoError(t, err)
+
+			_, err = tc.fen.ParseStrict()
+			var fenError *FenError
+			if assert.ErrorAs(t, err, &fenError) {
+				assert.Equal(t, tc.expectedField, fenError.Field, fenError.Error())
+				assert.Equal(t, tc.expectedOffset, fenError.Offset, fenError.Error())
+			}
+		})
+	}
+
+	reachableFens := []Fen{
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+		"4k3/6N1/5b2/4R3/8/8/8/4K3 b - - 0 1",
+	}
+	for _, fen := range reachableFens {
+		_, err := fen.ParseStrict()
+		assert.NoError(t, err, string(fen))
+	}
+}
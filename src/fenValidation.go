@@ -0,0 +1,140 @@
+package src
+
+import (
+	"strings"
+)
+
+// Parses fen & additionally rejects positions which can't be reached in a legal game
+func (fen Fen) ParseStrict() (Position, error) {
+	position, err := fen.Parse()
+	if err != nil {
+		return Position{}, err
+	}
+
+	fenSplit := strings.Split(string(fen), " ")
+	validations := []func(Position, []string) *FenError{
+		validateMaterial,
+		validateDoublePawnPush,
+		validateKingCheckers,
+		validateHalfMoveClock,
+	}
+	for _, validate := range validations {
+		if err := validate(position, fenSplit); err != nil {
+			return Position{}, err.inFen(fenSplit)
+		}
+	}
+
+	return position, nil
+}
+
+// Every piece beyond the initial set has to be promoted from a pawn
+func validateMaterial(position Position, fenSplit []string) *FenError {
+	for _, c := range []Color{White, Black} {
+		pieceBitboard := position.piecePlacement[c]
+		pawns := pieceBitboard[Pawn].countSquares()
+		if pawns > 8 {
+			return newFenError(FenPiecePlacement, 0, "%v has %d pawns", c, pawns)
+		}
+
+		promotedPieces := 0
+		for pt, initial := range map[PieceType]int{Knight: 2, Bishop: 2, Rook: 2, Queen: 1} {
+			if n := pieceBitboard[pt].countSquares(); n > initial {
+				promotedPieces += n - initial
+			}
+		}
+		if promotedPieces > 8-pawns {
+			return newFenError(FenPiecePlacement, 0, "%v has %d promoted pieces with %d pawns", c, promotedPieces, pawns)
+		}
+	}
+	return nil
+}
+
+// Castling rights require king & rook on their home squares
+func validateCastlingRights(position Position, fenSplit []string) *FenError {
+	cr := fenSplit[FenCastlingRights]
+	for i := 0; i < len(cr); i++ {
+		var c Color
+		var kingSq, rookSq Square
+		switch cr[i] {
+		case 'K':
+			c, kingSq, rookSq = White, E1, H1
+		case 'Q':
+			c, kingSq, rookSq = White, E1, A1
+		case 'k':
+			c, kingSq, rookSq = Black, E8, H8
+		case 'q':
+			c, kingSq, rookSq = Black, E8, A8
+		default:
+			continue
+		}
+
+		if position.pieceTypeOn(c, kingSq) != King || position.pieceTypeOn(c, rookSq) != Rook {
+			return newFenError(FenCastlingRights, i, "char %s: %v king or rook not on its home square", string(cr[i]), c)
+		}
+	}
+	return nil
+}
+
+// En passant target requires the pawn which was just pushed 2 squares behind it & the target to be empty
+func validateEnPassantTarget(position Position, fenSplit []string) *FenError {
+	ep := position.enPassantTarget
+	if ep >= 64 {
+		return nil
+	}
+
+	opp := position.activeColor.Opponent()
+	pushedTo := ep - 8
+	if opp == White {
+		pushedTo = ep + 8
+	}
+
+	if position.allOccupiedSquares&sqMask[ep].bitMask != 0 || position.pieceTypeOn(opp, pushedTo) != Pawn {
+		return newFenError(FenEnPassantTarget, 0, "no %v pawn pushed 2 squares through %s", opp, fenSplit[FenEnPassantTarget])
+	}
+	return nil
+}
+
+// Square the pawn was pushed from is left empty & the push resets the half move clock
+func validateDoublePawnPush(position Position, fenSplit []string) *FenError {
+	ep := position.enPassantTarget
+	if ep >= 64 {
+		return nil
+	}
+
+	pushedFrom := ep + 8
+	if position.activeColor == Black {
+		pushedFrom = ep - 8
+	}
+	if position.allOccupiedSquares&sqMask[pushedFrom].bitMask != 0 {
+		return newFenError(FenEnPassantTarget, 0, "%s is occupied, no pawn was pushed 2 squares from it", pushedFrom)
+	}
+
+	if position.halfMoveClock != 0 {
+		return newFenError(FenHalfMoveClock, 0, "half move clock %d should be 0 after a double pawn push", position.halfMoveClock)
+	}
+	return nil
+}
+
+// A move can check with at most 2 pieces & only one of them can be a pawn or knight
+func validateKingCheckers(position Position, fenSplit []string) *FenError {
+	kingCheckers := position.kingCheckers
+	if len(kingCheckers) > 2 {
+		return newFenError(FenActiveColor, 0, "%v is checked by %d pieces", position.activeColor, len(kingCheckers))
+	}
+	if len(kingCheckers) == 2 && !kingCheckers[0].pieceType.isSlider() && !kingCheckers[1].pieceType.isSlider() {
+		return newFenError(FenActiveColor, 0, "%v is checked by a %v & a %v", position.activeColor, kingCheckers[0].pieceType, kingCheckers[1].pieceType)
+	}
+	return nil
+}
+
+// Half move clock can't count more moves than have been played
+func validateHalfMoveClock(position Position, fenSplit []string) *FenError {
+	movesPlayed := 2 * (int(position.fullMoveNumber) - 1)
+	if position.activeColor == Black {
+		movesPlayed++
+	}
+	if int(position.halfMoveClock) > movesPlayed {
+		return newFenError(FenHalfMoveClock, 0, "half move clock %d is more than %d moves played", position.halfMoveClock, movesPlayed)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package src
+
+type Outcome int
+
+const (
+	Ongoing Outcome = iota
+	WhiteWins
+	BlackWins
+	Draw
+)
+
+// Result token of the outcome as in pgn
+func (o Outcome) String() string {
+	switch o {
+	case WhiteWins:
+		return "1-0"
+	case BlackWins:
+		return "0-1"
+	case Draw:
+		return "1/2-1/2"
+	default:
+		return "*"
+	}
+}
+
+type Reason int
+
+const (
+	NoReason Reason = iota
+	Checkmate
+	Stalemate
+	// draws which are automatic
+	FivefoldRepetition
+	SeventyFiveMoveRule
+	InsufficientMaterial
+	// draws which have to be claimed
+	ThreefoldRepetition
+	FiftyMoveRule
+)
+
+func (r Reason) String() string {
+	switch r {
+	case Checkmate:
+		return "checkmate"
+	case Stalemate:
+		return "stalemate"
+	case FivefoldRepetition:
+		return "fivefold repetition"
+	case SeventyFiveMoveRule:
+		return "seventy-five-move rule"
+	case InsufficientMaterial:
+		return "insufficient material"
+	case ThreefoldRepetition:
+		return "threefold repetition"
+	case FiftyMoveRule:
+		return "fifty-move rule"
+	default:
+		return "none"
+	}
+}
+
+type Result struct {
+	Outcome Outcome
+	Reason  Reason
+}
+
+// Position along with the history of the moves made on it
+type Game struct {
+	position Position
+	// zobrist keys of all positions of the game including the current one
+	keys  []uint64
+	undos []Undo
+}
+
+func NewGame(position Position) *Game {
+	return &Game{
+		position: position,
+		keys:     []uint64{position.zobristKey},
+	}
+}
+
+// Copy of the current position
+func (game *Game) Position() Position {
+	return game.position
+}
+
+// Moves made since the start of the game
+func (game *Game) Moves() []Move {
+	moves := make([]Move, len(game.undos))
+	for i, undo := range game.undos {
+		moves[i] = undo.move
+	}
+	return moves
+}
+
+// Makes a legal move
+func (game *Game) MakeMove(move Move) {
+	game.undos = append(game.undos, game.position.MakeMove(move))
+	game.keys = append(game.keys, game.position.zobristKey)
+}
+
+// Unmakes the last move, false if no move has been made
+func (game *Game) UnmakeMove() bool {
+	if len(game.undos) == 0 {
+		return false
+	}
+
+	game.position.UnmakeMove(game.undos[len(game.undos)-1])
+	game.undos = game.undos[:len(game.undos)-1]
+	game.keys = game.keys[:len(game.keys)-1]
+	return true
+}
+
+// Number of times the current position has occurred in the game
+func (game *Game) Repetitions() int {
+	current := len(game.keys) - 1
+	// positions before the last capture or pawn move can't repeat
+	oldest := current - int(game.position.halfMoveClock)
+	if oldest < 0 {
+		oldest = 0
+	}
+
+	repetitions := 0
+	// only positions with the same side to move can repeat
+	for i := current; i >= oldest; i -= 2 {
+		if game.keys[i] == game.keys[current] {
+			repetitions++
+		}
+	}
+	return repetitions
+}
+
+// Result of the game which ended by checkmate, stalemate or an automatic draw
+// Outcome is Ongoing if the game has not ended
+func (game *Game) Result() Result {
+	position := game.position
+
+	if len(GenerateAllMoves(position, 0)) == 0 {
+		if len(position.kingCheckers) == 0 {
+			return Result{Draw, Stalemate}
+		}
+		if position.activeColor == White {
+			return Result{BlackWins, Checkmate}
+		}
+		return Result{WhiteWins, Checkmate}
+	}
+
+	if game.Repetitions() >= 5 {
+		return Result{Draw, FivefoldRepetition}
+	}
+	if position.halfMoveClock >= 150 {
+		return Result{Draw, SeventyFiveMoveRule}
+	}
+	if position.InsufficientMaterial() {
+		return Result{Draw, InsufficientMaterial}
+	}
+	return Result{Ongoing, NoReason}
+}
+
+// Draw which the player to move can claim by threefold repetition or fifty-move rule
+// false if the game has ended or no draw can be claimed
+func (game *Game) ClaimableDraw() (Result, bool) {
+	if game.Result().Outcome != Ongoing {
+		return Result{}, false
+	}
+
+	if game.Repetitions() >= 3 {
+		return Result{Draw, ThreefoldRepetition}, true
+	}
+	if game.position.halfMoveClock >= 100 {
+		return Result{Draw, FiftyMoveRule}, true
+	}
+	return Result{}, false
+}
@@ -0,0 +1,87 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameResult(t *testing.T) {
+	// GRTC = Game Result Test Cases
+	type GRTC struct {
+		desc                string
+		positionFen         Fen
+		moves               []string
+		expectedResult      Result
+		expectedClaim       Result
+		expectedClaimable   bool
+		expectedRepetitions int
+	}
+
+	startingFen := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	knightShuffle := []string{"g1f3", "g8f6", "f3g1", "f6g8"}
+	repeat := func(moves []string, n int) (repeated []string) {
+		for i := 0; i < n; i++ {
+			repeated = append(repeated, moves...)
+		}
+		return
+	}
+
+	tcs := []GRTC{
+		{"ongoing", startingFen, []string{"e2e4"}, Result{Ongoing, NoReason}, Result{}, false, 1},
+		{"checkmate", startingFen, []string{"f2f3", "e7e5", "g2g4", "d8h4"}, Result{BlackWins, Checkmate}, Result{}, false, 1},
+		{"stalemate", "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", []string{}, Result{Draw, Stalemate}, Result{}, false, 1},
+		{"twofold repetition", startingFen, knightShuffle, Result{Ongoing, NoReason}, Result{}, false, 2},
+		{"threefold repetition", startingFen, repeat(knightShuffle, 2), Result{Ongoing, NoReason}, Result{Draw, ThreefoldRepetition}, true, 3},
+		{"fivefold repetition", startingFen, repeat(knightShuffle, 4), Result{Draw, FivefoldRepetition}, Result{}, false, 5},
+		{"repetition broken by pawn move", startingFen, append(append(repeat(knightShuffle, 1), "e2e4", "e7e5"), repeat(knightShuffle, 1)...), Result{Ongoing, NoReason}, Result{}, false, 2},
+		{"fifty-move rule", "7k/8/6K1/8/8/8/8/R7 w - - 99 80", []string{"a1a2"}, Result{Ongoing, NoReason}, Result{Draw, FiftyMoveRule}, true, 1},
+		{"seventy-five-move rule", "7k/8/6K1/8/8/8/8/R7 w - - 149 100", []string{"a1a2"}, Result{Draw, SeventyFiveMoveRule}, Result{}, false, 1},
+		{"insufficient material", "4k3/8/8/8/8/8/3r4/4K3 w - - 0 1", []string{"e1d2"}, Result{Draw, InsufficientMaterial}, Result{}, false, 1},
+		{"checkmate on the seventy-fifth move", "7k/8/6K1/8/8/8/8/R7 w - - 149 100", []string{"a1a8"}, Result{WhiteWins, Checkmate}, Result{}, false, 1},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, err := tc.positionFen.Parse()
+			assert.NoError(t, err)
+			game := NewGame(position)
+			for _, uci := range tc.moves {
+				move, err := game.Position().ParseUCIMove(uci)
+				assert.NoError(t, err)
+				game.MakeMove(move)
+			}
+
+			assert.Equal(t, tc.expectedResult, game.Result())
+			claim, claimable := game.ClaimableDraw()
+			assert.Equal(t, tc.expectedClaimable, claimable)
+			assert.Equal(t, tc.expectedClaim, claim)
+			assert.Equal(t, tc.expectedRepetitions, game.Repetitions())
+		})
+	}
+}
+
+func TestGameUnmakeMove(t *testing.T) {
+	position, _ := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
+	game := NewGame(position)
+	assert.False(t, game.UnmakeMove())
+
+	for _, uci := range []string{"f2f3", "e7e5", "g2g4", "d8h4"} {
+		move, _ := game.Position().ParseUCIMove(uci)
+		game.MakeMove(move)
+	}
+	assert.Equal(t, Result{BlackWins, Checkmate}, game.Result())
+	assert.Len(t, game.Moves(), 4)
+
+	assert.True(t, game.UnmakeMove())
+	assert.Equal(t, Result{Ongoing, NoReason}, game.Result())
+	assert.Len(t, game.Moves(), 3)
+	assert.Equal(t, Fen("rnbqkbnr/pppp1ppp/8/4p3/6P1/5P2/PPPPP2P/RNBQKBNR b KQkq g3 0 2"), game.Position().Fen())
+}
+
+func TestOutcomeString(t *testing.T) {
+	assert.Equal(t, "1-0", WhiteWins.String())
+	assert.Equal(t, "0-1", BlackWins.String())
+	assert.Equal(t, "1/2-1/2", Draw.String())
+	assert.Equal(t, "*", Ongoing.String())
+}
@@ -0,0 +1,101 @@
+package src
+
+// Neither side can checkmate by any sequence of legal moves, i.e. K vs K,
+// K + minor vs K & positions with bishops of the same square color only
+func (position Position) InsufficientMaterial() bool {
+	return position.InsufficientMatingMaterial(White) && position.InsufficientMatingMaterial(Black)
+}
+
+// Color can't checkmate even with the help of the opponent,
+// so the opponent running out of time only draws
+func (position Position) InsufficientMatingMaterial(c Color) bool {
+	ours, theirs := position.piecePlacement[c], position.piecePlacement[c.Opponent()]
+
+	if ours[Pawn]|ours[Rook]|ours[Queen] != 0 {
+		return false
+	}
+
+	theirPieces := position.occupiedSquaresColorWise[c.Opponent()] &^ theirs[King]
+	knights, bishops := ours[Knight], ours[Bishop]
+	switch {
+	case knights == 0 && bishops == 0:
+		// lone king
+		return true
+	case bishops == 0 && knights.countSquares() == 1:
+		// knight can mate only when the opponent's pieces block their king
+		return theirPieces == 0
+	case knights == 0 && (bishops&lightSquares == 0 || bishops&darkSquares == 0):
+		// bishops of one square color can mate only when the opponent
+		// has pieces to block their king on the squares of the other color
+		sameColorSquares := lightSquares
+		if bishops&lightSquares == 0 {
+			sameColorSquares = darkSquares
+		}
+		return theirPieces&^(theirs[Bishop]&sameColorSquares) == 0
+	default:
+		return false
+	}
+}
+
+// Only kings & pawns are left, no pawn can ever move & neither king can reach
+// an undefended pawn of the opponent. Unlike InsufficientMaterial, the position
+// is analysed beyond the material, so this stronger detection is opt-in.
+func (position Position) BlockedPawnFortress() bool {
+	white, black := position.piecePlacement[White], position.piecePlacement[Black]
+	pawns := white[Pawn] | black[Pawn]
+	if position.allOccupiedSquares != pawns|white[King]|black[King] || pawns == 0 {
+		return false
+	}
+
+	for _, c := range []Color{White, Black} {
+		up := north
+		if c == Black {
+			up = south
+		}
+
+		ourPawns, theirPawns := position.piecePlacement[c][Pawn], position.piecePlacement[c.Opponent()][Pawn]
+
+		// every pawn is blocked by a pawn in front of it & has nothing to capture
+		if ourPawns.shift(up)&^pawns != 0 || pawnAttacks(c, ourPawns)&theirPawns != 0 {
+			return false
+		}
+
+		// our king can't step on pawns or squares attacked by their pawns, which never move
+		kingRegion := reachableSquares(position.piecePlacement[c][King], pawns|pawnAttacks(c.Opponent(), theirPawns))
+
+		undefendedPawns := theirPawns &^ pawnAttacks(c.Opponent(), theirPawns)
+		if kingAttacks(kingRegion)&undefendedPawns != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Squares reachable by the king from its square without stepping on the blocked squares
+func reachableSquares(king, blocked Bitboard) Bitboard {
+	region := king
+	for {
+		expanded := region | kingAttacks(region)&^blocked
+		if expanded == region {
+			return region
+		}
+		region = expanded
+	}
+}
+
+// Squares attacked by any of the kings on the bitboard
+func kingAttacks(bb Bitboard) (attacks Bitboard) {
+	for ; bb > 0; bb &= bb - 1 {
+		attacks |= KingAttacks[bb.leftmostSignificantSquare()]
+	}
+	return attacks
+}
+
+// Squares attacked by any of the pawns of the color on the bitboard
+func pawnAttacks(c Color, bb Bitboard) (attacks Bitboard) {
+	for ; bb > 0; bb &= bb - 1 {
+		attacks |= PawnAttacks[c][bb.leftmostSignificantSquare()]
+	}
+	return attacks
+}
@@ -0,0 +1,72 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsufficientMaterial(t *testing.T) {
+	// IMTC = Insufficient Material Test Cases
+	type IMTC struct {
+		desc                         string
+		positionFen                  Fen
+		expectedInsufficientMaterial bool
+		expectedWhiteCantMate        bool
+		expectedBlackCantMate        bool
+	}
+
+	tcs := []IMTC{
+		{"king vs king", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", true, true, true},
+		{"king & knight vs king", "4k3/8/8/8/8/8/8/4KN2 w - - 0 1", true, true, true},
+		{"king & bishop vs king", "4k3/8/8/8/8/8/8/2B1K3 w - - 0 1", true, true, true},
+		{"king & two knights vs king", "4k3/8/8/8/8/8/8/1NN1K3 w - - 0 1", false, false, true},
+		{"bishops of the same color", "4kb2/8/8/8/8/8/8/2B1K3 w - - 0 1", true, true, true},
+		{"bishops of opposite colors", "2b1k3/8/8/8/8/8/8/2B1K3 w - - 0 1", false, false, false},
+		{"two bishops of the same color vs king", "4k3/8/8/8/8/8/1B6/B3K3 w - - 0 1", true, true, true},
+		{"two bishops of opposite colors vs king", "4k3/8/8/8/8/8/B7/B3K3 w - - 0 1", false, false, true},
+		{"king & knight vs king & pawn", "4k3/4p3/8/8/8/8/8/4KN2 w - - 0 1", false, false, false},
+		{"king & rook vs king", "4k3/8/8/8/8/8/8/4KR2 w - - 0 1", false, false, true},
+		{"king & bishop vs king & knight", "4kn2/8/8/8/8/8/8/2B1K3 w - - 0 1", false, false, false},
+		{"king & bishop vs king & queen", "4kq2/8/8/8/8/8/8/2B1K3 w - - 0 1", false, false, false},
+		{"starting position", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", false, false, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, err := tc.positionFen.Parse()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedInsufficientMaterial, position.InsufficientMaterial())
+			assert.Equal(t, tc.expectedWhiteCantMate, position.InsufficientMatingMaterial(White))
+			assert.Equal(t, tc.expectedBlackCantMate, position.InsufficientMatingMaterial(Black))
+		})
+	}
+}
+
+func TestBlockedPawnFortress(t *testing.T) {
+	// BPFTC = Blocked Pawn Fortress Test Cases
+	type BPFTC struct {
+		desc             string
+		positionFen      Fen
+		expectedFortress bool
+	}
+
+	tcs := []BPFTC{
+		{"pawns locked across the board", "4k3/8/8/p1p1p1p1/P1P1P1P1/8/8/4K3 w - - 0 1", true},
+		{"chain of locked pawns", "4k3/8/8/1p1p1p1p/1P1P1P1P/8/8/4K3 b - - 0 1", true},
+		{"king walks around the pawns", "4k3/8/8/p1p1p3/P1P1P3/8/8/4K3 w - - 0 1", false},
+		{"king inside the opponent's camp", "8/8/8/p1p1p1p1/P1P1P1P1/8/1k6/4K3 w - - 0 1", false},
+		{"pawn can capture", "4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1", false},
+		{"pawn can push", "4k3/8/8/p1p1p1p1/P1P1P1P1/8/7P/4K3 w - - 0 1", false},
+		{"pieces besides pawns", "4k3/8/8/p1p1p1p1/P1P1P1P1/8/8/4KN2 w - - 0 1", false},
+		{"kings only", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, err := tc.positionFen.Parse()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFortress, position.BlockedPawnFortress())
+		})
+	}
+}
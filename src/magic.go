@@ -0,0 +1,66 @@
+package src
+
+/*
+	Rook & bishop attacks by fancy magic bitboards
+	https://www.chessprogramming.org/Magic_Bitboards
+*/
+
+type magic struct {
+	// relevant occupancy, the slider's rays without the edge squares
+	mask   Bitboard
+	number uint64
+	shift  uint
+	// attacks indexed by the magic index of the occupancy
+	attacks []Bitboard
+}
+
+var rookMagics [64]magic
+var bishopMagics [64]magic
+
+func (m *magic) index(occ Bitboard) uint64 {
+	return (uint64(occ&m.mask) * m.number) >> m.shift
+}
+
+func magicRookAttacks(sq Square, occ Bitboard) Bitboard {
+	m := &rookMagics[sq]
+	return m.attacks[m.index(occ)]
+}
+
+func magicBishopAttacks(sq Square, occ Bitboard) Bitboard {
+	m := &bishopMagics[sq]
+	return m.attacks[m.index(occ)]
+}
+
+// precalculate attack tables of rooks & bishops from Hyperbola Quintessence, requires square & file rank masks
+func GenerateMagics() {
+	ranks18, filesAH := rankMasks[0]|rankMasks[7], fileMasks[0]|fileMasks[7]
+
+	for sq := Square(0); sq < 64; sq++ {
+		rookMask := sqMask[sq].sliderMaskEx[file]&^ranks18 | sqMask[sq].sliderMaskEx[rank]&^filesAH
+		rookMagics[sq] = newMagic(sq, rookMask, rookMagicNumbers[sq], hqRookAttacks)
+
+		bishopMask := (sqMask[sq].sliderMaskEx[diagonal] | sqMask[sq].sliderMaskEx[antiDiagonal]) &^ (ranks18 | filesAH)
+		bishopMagics[sq] = newMagic(sq, bishopMask, bishopMagicNumbers[sq], hqBishopAttacks)
+	}
+}
+
+// Fills the attack table of the magic number for every occupancy of the mask
+func newMagic(sq Square, mask Bitboard, number uint64, attacksOf func(Square, Bitboard) Bitboard) magic {
+	bits := mask.countSquares()
+	m := magic{mask: mask, number: number, shift: uint(64 - bits), attacks: make([]Bitboard, 1<<bits)}
+	filled := make([]bool, 1<<bits)
+
+	// every subset of the mask by carry-rippler enumeration
+	for subset := Bitboard(0); ; {
+		index, attacks := m.index(subset), attacksOf(sq, subset)
+		if filled[index] && m.attacks[index] != attacks {
+			panic("magic number collision on square " + sq.String())
+		}
+		m.attacks[index], filled[index] = attacks, true
+
+		subset = (subset - mask) & mask
+		if subset == 0 {
+			return m
+		}
+	}
+}
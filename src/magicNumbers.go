@@ -0,0 +1,42 @@
+package src
+
+// Magic numbers of the rooks & bishops indexed by square, found by a search of sparse random numbers
+// which map every relevant occupancy of the square to an index of its attacks without collisions
+
+var rookMagicNumbers = [64]uint64{
+	0x008000908064C000, 0x0040200040001000, 0x0180100080A0010A, 0x8880041000800800,
+	0x1200100201200804, 0x0200020004011008, 0x2180010000800600, 0x0200005088210204,
+	0x0000800080204001, 0x1000804000802001, 0x8240801000200080, 0x8611001004200900,
+	0x008180800C001800, 0x0100800200800400, 0x0A02000102000408, 0x8020802300104280,
+	0x0080004000402000, 0xE010104000402000, 0x0800808010002000, 0xA280210008100100,
+	0x0001818014000800, 0xA002010100080400, 0x0008040088020130, 0x0001020004048845,
+	0x0081826280004004, 0x2020810900284000, 0x0200100080802000, 0x0200080080100080,
+	0x8083080100100500, 0x4406000901000400, 0x0005020080800100, 0x0090204200008114,
+	0x0010400094800420, 0x0900804000802002, 0x0201001841002000, 0x4100080080801000,
+	0x4540040080800800, 0x0000800400800200, 0x9281800100808200, 0x8004048102000854,
+	0x4420802040008006, 0x0880500020004002, 0x0801200241050010, 0x8400080010008080,
+	0x0008000500090010, 0x0082009084020008, 0x4012000108020004, 0x9000104D08860004,
+	0x2004204114800100, 0x0148802112400300, 0x0202842000100880, 0x001B080080900080,
+	0x001A002008100600, 0x0004008004020080, 0x5181000600040300, 0x0000044401128A00,
+	0x8044110480002441, 0x1023012082044112, 0x00804080200A0012, 0x000420310A004A42,
+	0x0023001004020801, 0x0882001008040102, 0x000230088118020C, 0x0000019025040042,
+}
+
+var bishopMagicNumbers = [64]uint64{
+	0x1010220204082A00, 0x80E0020202002804, 0x2008480104200020, 0x000220920280002D,
+	0x32040421000B0284, 0x1002080404000400, 0x0004160892080040, 0x2203024206204201,
+	0x0002404264010200, 0x1120908408428124, 0xB100424403002280, 0x240008060440C288,
+	0x2040040420490400, 0x0100620210040022, 0x0400084104202028, 0x0010050080908820,
+	0x0C90A04490824802, 0x000200A008210130, 0x0C08001000204010, 0x0008000186014480,
+	0x0601044820080021, 0x0002000101013100, 0x1400A08108080204, 0x0250401104485410,
+	0x4820240810142843, 0x0009142A20182200, 0x0848140048440020, 0x2020120000400440,
+	0x0108840200802003, 0x0009070082009492, 0x020C0C0038424245, 0xCA44005808210410,
+	0x8011212000500404, 0x2028840510101008, 0x0004042A00041400, 0x0624020080980080,
+	0x1820410040840040, 0x2201004202050100, 0x402A088A24040224, 0x0242061040002400,
+	0x90020202400821A0, 0x00C9009004E01002, 0x58C2060202023100, 0x0000012214040800,
+	0x0210846810100200, 0x0004208081010200, 0x01A4108404442100, 0x8054082C80280106,
+	0x0004144904104208, 0x00324C0A11104000, 0x1000020231040100, 0x2080001042020004,
+	0x0544021020288104, 0x1103501408083020, 0x4010451004960002, 0x003010091C44902C,
+	0x0102402884202000, 0x0480804C00841086, 0x04602C8602210400, 0x0000004000420200,
+	0x0040000020442C18, 0x4483804089094100, 0x80000B0248020400, 0x0045010808008680,
+}
@@ -0,0 +1,55 @@
+package src
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Random occupancies with about a third of the squares set
+func randomOccupancies(n int) []Bitboard {
+	random := rand.New(rand.NewSource(1))
+	occupancies := make([]Bitboard, n)
+	for i := range occupancies {
+		occupancies[i] = Bitboard(random.Uint64() & random.Uint64())
+	}
+	return occupancies
+}
+
+func TestMagicAttacks(t *testing.T) {
+	occupancies := randomOccupancies(1000)
+
+	for sq := Square(0); sq < 64; sq++ {
+		for _, occ := range occupancies {
+			if !assert.Equal(t, hqRookAttacks(sq, occ), magicRookAttacks(sq, occ), "rook on %s, occupancy\n%v", sq, occ) ||
+				!assert.Equal(t, hqBishopAttacks(sq, occ), magicBishopAttacks(sq, occ), "bishop on %s, occupancy\n%v", sq, occ) {
+				return
+			}
+		}
+	}
+}
+
+func BenchmarkSliderAttacks(b *testing.B) {
+	occupancies := randomOccupancies(1024)
+
+	benchmarks := []struct {
+		name    string
+		attacks func(Square, Bitboard) Bitboard
+	}{
+		{"rook/hyperbola", hqRookAttacks},
+		{"rook/magic", magicRookAttacks},
+		{"bishop/hyperbola", hqBishopAttacks},
+		{"bishop/magic", magicBishopAttacks},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			var sink Bitboard
+			for i := 0; i < b.N; i++ {
+				sink ^= bm.attacks(Square(i&63), occupancies[i&1023])
+			}
+			_ = sink
+		})
+	}
+}
@@ -1,17 +1,22 @@
 package src
 
+// Generates all strictly legal moves for the active color
 func GenerateAllMoves(position Position, depth int) (moveList []Move) {
+	// king moves are already filtered against king danger squares
+	moveList = generateKingMoves(position)
 
-	if len(position.kingCheckers) == 2 {
+	if len(position.kingCheckers) > 1 {
 		// double check
-		// only generate king moves
+		// only king moves are legal
 		return
 	}
 
-	// if position.isCheck {
-	// 	// 1. generate king moves
-	// 	return
-	// }
+	// moves of other pieces are restricted to capture & push masks
+	// and pinned pieces are restricted to their pin rays
+	moveList = append(moveList, generatePawnMoves(position)...)
+	for pt := Knight; pt < King; pt++ {
+		moveList = append(moveList, pt.generateMoves(position)...)
+	}
 
 	return moveList
 }
@@ -21,7 +26,7 @@ func (pt PieceType) generateMoves(position Position) (moveList []Move) {
 	// capture mask and push mask
 	cM, pM := position.captureMask, position.pushMask
 
-	us, opponent := position.activeColor, !position.activeColor
+	us, opponent := position.activeColor, position.activeColor.Opponent()
 	ourOccupiedSquares, opponentOccupiedSquares := position.occupiedSquaresColorWise[us], position.occupiedSquaresColorWise[opponent]
 	pieceBitboard := position.piecePlacement[us][pt]
 
@@ -33,7 +38,8 @@ func (pt PieceType) generateMoves(position Position) (moveList []Move) {
 		attackingSquares := pt.attackBbSP(us, sq, ourOccupiedSquares, opponentOccupiedSquares)
 
 		// Calculating possible squares where piece can attack by removing our pieces
-		possibleSquares := attackingSquares.removePieces(ourOccupiedSquares)
+		// pinned piece can only move along its pin ray
+		possibleSquares := attackingSquares.removePieces(ourOccupiedSquares) & position.pinRay(sq)
 
 		// Calculating possible squares where piece can capture
 		captureSquares := possibleSquares & opponentOccupiedSquares & cM
@@ -50,7 +56,7 @@ func (pt PieceType) generateMoves(position Position) (moveList []Move) {
 
 func generatePawnMoves(position Position) (moveList []Move) {
 
-	us, opponent := position.activeColor, !position.activeColor
+	us, opponent := position.activeColor, position.activeColor.Opponent()
 
 	var up, upRight, upLeft Direction
 	var a, b, c, d Square
@@ -60,15 +66,18 @@ func generatePawnMoves(position Position) (moveList []Move) {
 		upRight = northEast
 		upLeft = northWest
 		a, b = 8, 47 // rank 2-6
-		c, d = 8, 16 // rank 2
+		c, d = 8, 15 // rank 2
 	} else {
 		up = south
 		upRight = southWest
 		upLeft = southEast
 		a, b = 16, 55 // rank 7,3
-		c, d = 48, 56 // rank 7
+		c, d = 48, 55 // rank 7
 	}
 
+	// capture mask and push mask
+	cM, pM := position.captureMask, position.pushMask
+
 	_, opponentSquares := position.occupiedSquaresColorWise[us], position.occupiedSquaresColorWise[opponent]
 	pieceBitboard := position.piecePlacement[us][Pawn]
 	occ := position.allOccupiedSquares
@@ -78,35 +87,45 @@ func generatePawnMoves(position Position) (moveList []Move) {
 		// position of piece: 0-63
 		sq := pieceBitboard.leftmostSignificantSquare()
 
+		// pinned pawn can only move along its pin ray
+		pinRay := position.pinRay(sq)
+
 		if sq >= a && sq <= b {
 			// Possible squares where pawn can jump 1 square forward
 			jumpableSquares := Bitboard(1 << sq).shift(up)
 			jumpableSquares = jumpableSquares.removePieces(occ)
 
-			moveList = append(moveList, jumpableSquares.spawnMoves(sq, Normal)...)
+			moveList = append(moveList, (jumpableSquares&pM&pinRay).spawnMoves(sq, Normal)...)
 
 			// Possible squares where pawn can jump 2 square forward from starting square
 			if jumpableSquares != 0 && sq >= c && sq <= d {
 				jumpable2Squares := Bitboard(1 << sq).shift(up).shift(up)
-				jumpable2Squares = jumpable2Squares.removePieces(occ)
+				jumpable2Squares = jumpable2Squares.removePieces(occ) & pM & pinRay
 				moveList = append(moveList, jumpable2Squares.spawnMoves(sq, DoublePawnPush)...)
 			}
 
 			// Possible squares where pawn can attack
-			attackingSquares := PawnAttacks[us][sq] & opponentSquares
+			attackingSquares := PawnAttacks[us][sq] & opponentSquares & cM & pinRay
 
 			moveList = append(moveList, attackingSquares.spawnMoves(sq, Capture)...)
 
 			// enpassant capture condition
-			if ep < 64 && (Bitboard(1<<ep).shift(-upRight)|Bitboard(1<<ep).shift(-upLeft))&Bitboard(1<<sq) != 0 {
-				moveList = append(moveList, EnPassant+Move(sq+Square(ep<<6)))
+			// the pawn which just made a double push must be behind the target square
+			if ep < 64 && (Bitboard(1<<ep).shift(-upRight)|Bitboard(1<<ep).shift(-upLeft))&Bitboard(1<<sq) != 0 && Bitboard(1<<ep)&pinRay != 0 &&
+				Bitboard(1<<ep).shift(-up)&position.piecePlacement[opponent][Pawn] != 0 {
+				// en passant evades check either by capturing the checking pawn or by blocking on the target square
+				// & removing both pawns must not expose our king along the rank
+				epMove := EnPassant + Move(sq+Square(ep<<6))
+				if (Bitboard(1<<ep).shift(-up)&cM != 0 || Bitboard(1<<ep)&pM != 0) && !position.enPassantExposesKing(epMove) {
+					moveList = append(moveList, epMove)
+				}
 			}
 		} else {
 			// promotion to major piece
 
 			// Possible squares where pawn can jump 1 square forward
 			jumpableSquares := Bitboard(1 << sq).shift(up)
-			jumpableSquares = jumpableSquares.removePieces(occ)
+			jumpableSquares = jumpableSquares.removePieces(occ) & pM & pinRay
 
 			if jumpableSquares != 0 {
 				q := jumpableSquares.leftmostSignificantSquare()
@@ -117,7 +136,7 @@ func generatePawnMoves(position Position) (moveList []Move) {
 			}
 
 			// Possible squares where pawn can attack
-			attackingSquares := (Bitboard(1<<sq).shift(upRight) + Bitboard(1<<sq).shift(upLeft)) & opponentSquares
+			attackingSquares := (Bitboard(1<<sq).shift(upRight) + Bitboard(1<<sq).shift(upLeft)) & opponentSquares & cM & pinRay
 
 			for ; attackingSquares > 0; attackingSquares &= attackingSquares - 1 {
 				q := attackingSquares.leftmostSignificantSquare()
@@ -132,10 +151,10 @@ func generatePawnMoves(position Position) (moveList []Move) {
 }
 
 func generateKingMoves(position Position) (moveList []Move) {
-	us, opponent := position.activeColor, !position.activeColor
+	us, opponent := position.activeColor, position.activeColor.Opponent()
 	ourOccupiedSquares, opponentOccupiedSquares := position.occupiedSquaresColorWise[us], position.occupiedSquaresColorWise[opponent]
 	// usKDS = our King Danger Squares
-	// usKDS := position.ourKingDangerSquares
+	usKDS := position.ourKingDangerSquares
 	allOccupiedSquares := position.allOccupiedSquares
 	bitboard := position.piecePlacement[us][King]
 	castling := position.castlingRights[us]
@@ -146,8 +165,8 @@ func generateKingMoves(position Position) (moveList []Move) {
 
 		attackingSquares := KingAttacks[sq]
 
-		// Possible squares where king can jump
-		possibleSquares := attackingSquares.removePieces(ourOccupiedSquares)
+		// Possible squares where king can jump without being attacked
+		possibleSquares := attackingSquares.removePieces(ourOccupiedSquares).removePieces(usKDS)
 
 		captureSquares := possibleSquares & opponentOccupiedSquares
 
@@ -158,24 +177,41 @@ func generateKingMoves(position Position) (moveList []Move) {
 		moveList = append(moveList, jumpableSquares.spawnMoves(sq, Normal)...)
 	}
 
+	// king can't castle out of check
+	if len(position.kingCheckers) > 0 {
+		return moveList
+	}
+
 	// Bitboard for squares between king and respective rook
 	var kksr, kqsr Bitboard
+	// Bitboard for squares king passes through while castling
+	var kksp, kqsp Bitboard
 	var kingSideCastling, queenSideCastling Move
 	if us == White {
 		kksr, kqsr = 0x60, 0xE
+		kksp, kqsp = 0x60, 0xC
 		kingSideCastling, queenSideCastling = WhiteKingSideCastling, WhiteQueenSideCastling
 	} else {
 		kksr, kqsr = 0x6000000000000000, 0xE00000000000000
+		kksp, kqsp = 0x6000000000000000, 0xC00000000000000
 		kingSideCastling, queenSideCastling = BlackKingSideCastling, BlackQueenSideCastling
 	}
 	// castling
-	// is castling available & are there any peices between king and rook
-	if castling.kingSide && kksr&allOccupiedSquares == 0 {
+	// is castling available, are king & rook on their squares, are there any peices between king and rook
+	// & is king passing through an attacked square
+	if castling.kingSide && position.castlingPiecesInPlace(kingSideCastling) && kksr&allOccupiedSquares == 0 && kksp&usKDS == 0 {
 		moveList = append(moveList, kingSideCastling)
 	}
-	if castling.queenSide && kqsr&allOccupiedSquares == 0 {
+	if castling.queenSide && position.castlingPiecesInPlace(queenSideCastling) && kqsr&allOccupiedSquares == 0 && kqsp&usKDS == 0 {
 		moveList = append(moveList, queenSideCastling)
 	}
 
 	return moveList
 }
+
+// King & rook of the side to move are on their starting squares for the castling move
+func (position Position) castlingPiecesInPlace(castlingMove Move) bool {
+	kingFrom, _, rookFrom, _ := castlingSquares(castlingMove)
+	ourPieces := position.piecePlacement[position.activeColor]
+	return ourPieces[King]&sqMask[kingFrom].bitMask != 0 && ourPieces[Rook]&sqMask[rookFrom].bitMask != 0
+}
@@ -21,24 +21,24 @@ func TestGenerateKnightMoves(t *testing.T) {
 			"startingPosition",
 			startingPosition,
 			[]Move{
-				squaresToMove(b1, a3, Normal),
-				squaresToMove(b1, c3, Normal),
-				squaresToMove(g1, f3, Normal),
-				squaresToMove(g1, h3, Normal),
+				squaresToMove(B1, A3, Normal),
+				squaresToMove(B1, C3, Normal),
+				squaresToMove(G1, F3, Normal),
+				squaresToMove(G1, H3, Normal),
 			},
 		},
 		{
 			"positionAGvsMG1",
 			positionAGvsMG1,
 			[]Move{
-				squaresToMove(c5, a4, Normal),
-				squaresToMove(c5, b3, Normal),
-				squaresToMove(c5, d3, Normal),
-				squaresToMove(c5, e4, Normal),
-				squaresToMove(c5, e6, Normal),
-				squaresToMove(c5, d7, Normal),
-				squaresToMove(c5, b7, Normal),
-				squaresToMove(c5, a6, Normal),
+				squaresToMove(C5, A4, Normal),
+				squaresToMove(C5, B3, Normal),
+				squaresToMove(C5, D3, Normal),
+				squaresToMove(C5, E4, Normal),
+				squaresToMove(C5, E6, Normal),
+				squaresToMove(C5, D7, Normal),
+				squaresToMove(C5, B7, Normal),
+				squaresToMove(C5, A6, Normal),
 			},
 		},
 	}
@@ -57,25 +57,25 @@ func TestGenerateRookMoves(t *testing.T) {
 			"pos1",
 			positionAGvsMG,
 			[]Move{
-				squaresToMove(d1, a1, Normal),
-				squaresToMove(d1, b1, Normal),
-				squaresToMove(d1, c1, Normal),
-				squaresToMove(d1, e1, Normal),
-				squaresToMove(d1, f1, Normal),
-				squaresToMove(d1, d2, Normal),
-				squaresToMove(d1, d3, Normal),
-				squaresToMove(d1, d4, Normal),
-				squaresToMove(c4, a4, Normal),
-				squaresToMove(c4, b4, Normal),
-				squaresToMove(c4, d4, Normal),
-				squaresToMove(c4, e4, Normal),
-				squaresToMove(c4, f4, Normal),
-				squaresToMove(c4, g4, Normal),
-				squaresToMove(c4, h4, Normal),
-				squaresToMove(c4, c3, Normal),
-				squaresToMove(c4, c2, Normal),
-				squaresToMove(c4, c1, Normal),
-				squaresToMove(c4, c5, Capture),
+				squaresToMove(D1, A1, Normal),
+				squaresToMove(D1, B1, Normal),
+				squaresToMove(D1, C1, Normal),
+				squaresToMove(D1, E1, Normal),
+				squaresToMove(D1, F1, Normal),
+				squaresToMove(D1, D2, Normal),
+				squaresToMove(D1, D3, Normal),
+				squaresToMove(D1, D4, Normal),
+				squaresToMove(C4, A4, Normal),
+				squaresToMove(C4, B4, Normal),
+				squaresToMove(C4, D4, Normal),
+				squaresToMove(C4, E4, Normal),
+				squaresToMove(C4, F4, Normal),
+				squaresToMove(C4, G4, Normal),
+				squaresToMove(C4, H4, Normal),
+				squaresToMove(C4, C3, Normal),
+				squaresToMove(C4, C2, Normal),
+				squaresToMove(C4, C1, Normal),
+				squaresToMove(C4, C5, Capture),
 			},
 		},
 	}
@@ -97,18 +97,18 @@ func TestGenerateQueenMoves(t *testing.T) {
 			"pos1",
 			positionPraggvsMG,
 			[]Move{
-				squaresToMove(a8, a7, Normal),
-				squaresToMove(a8, a6, Normal),
-				squaresToMove(a8, b8, Normal),
-				squaresToMove(a8, c8, Normal),
-				squaresToMove(a8, d8, Normal),
-				squaresToMove(a8, e8, Normal),
-				squaresToMove(a8, f8, Normal),
-				squaresToMove(a8, g8, Normal),
-				squaresToMove(a8, h8, Normal),
-				squaresToMove(a8, b7, Normal),
-				squaresToMove(a8, c6, Normal),
-				squaresToMove(a8, d5, Normal),
+				squaresToMove(A8, A7, Normal),
+				squaresToMove(A8, A6, Normal),
+				squaresToMove(A8, B8, Normal),
+				squaresToMove(A8, C8, Normal),
+				squaresToMove(A8, D8, Normal),
+				squaresToMove(A8, E8, Normal),
+				squaresToMove(A8, F8, Normal),
+				squaresToMove(A8, G8, Normal),
+				squaresToMove(A8, H8, Normal),
+				squaresToMove(A8, B7, Normal),
+				squaresToMove(A8, C6, Normal),
+				squaresToMove(A8, D5, Normal),
 			},
 		},
 	}
@@ -131,21 +131,21 @@ func TestGenerateBishopMoves(t *testing.T) {
 			"positionAGvsMG",
 			positionAGvsMG,
 			[]Move{
-				squaresToMove(a5, b4, Normal),
-				squaresToMove(a5, c3, Normal),
-				squaresToMove(a5, d2, Normal),
-				squaresToMove(a5, e1, Normal),
-				squaresToMove(a5, b6, Normal),
-				squaresToMove(a5, c7, Capture),
-				squaresToMove(d5, e4, Normal),
-				squaresToMove(d5, f3, Normal),
-				squaresToMove(d5, g2, Normal),
-				squaresToMove(d5, h1, Normal),
-				squaresToMove(d5, c6, Normal),
-				squaresToMove(d5, b7, Normal),
-				squaresToMove(d5, a8, Normal),
-				squaresToMove(d5, e6, Normal),
-				squaresToMove(d5, f7, Capture),
+				squaresToMove(A5, B4, Normal),
+				squaresToMove(A5, C3, Normal),
+				squaresToMove(A5, D2, Normal),
+				squaresToMove(A5, E1, Normal),
+				squaresToMove(A5, B6, Normal),
+				squaresToMove(A5, C7, Capture),
+				squaresToMove(D5, E4, Normal),
+				squaresToMove(D5, F3, Normal),
+				squaresToMove(D5, G2, Normal),
+				squaresToMove(D5, H1, Normal),
+				squaresToMove(D5, C6, Normal),
+				squaresToMove(D5, B7, Normal),
+				squaresToMove(D5, A8, Normal),
+				squaresToMove(D5, E6, Normal),
+				squaresToMove(D5, F7, Capture),
 			},
 		},
 	}
@@ -161,7 +161,7 @@ func TestGenerateKingMoves(t *testing.T) {
 	startingPosition, _ := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
 	positionAGvsMG1, _ := Fen("2r5/2r2ppp/5k2/B1NBb3/2R5/6P1/P4P1P/3R2K1 b - - 2 35").Parse()
 	pos1, _ := Fen("r3k1br/8/8/8/8/8/8/R1q1K2R w KQkq - 0 1").Parse()
-	pos2, _ := Fen("r3k1br/8/8/8/8/8/8/R1q1K2R b KQkq - 0 1").Parse()
+	pos2, _ := Fen("r3k1br/8/8/8/8/8/8/R1qBK2R b KQkq - 0 1").Parse()
 
 	tcs := []MoveGenTestCase{
 		{
@@ -173,34 +173,29 @@ func TestGenerateKingMoves(t *testing.T) {
 			"positionAGvsMG1",
 			positionAGvsMG1,
 			[]Move{
-				squaresToMove(f6, e6, Normal),
-				squaresToMove(f6, g6, Normal),
-				squaresToMove(f6, g5, Normal),
-				squaresToMove(f6, f5, Normal),
-				squaresToMove(f6, e7, Normal),
+				squaresToMove(F6, G6, Normal),
+				squaresToMove(F6, G5, Normal),
+				squaresToMove(F6, F5, Normal),
+				squaresToMove(F6, E7, Normal),
 			},
 		},
 		{
 			"pos1",
 			pos1,
 			[]Move{
-				squaresToMove(e1, d1, Normal),
-				squaresToMove(e1, d2, Normal),
-				squaresToMove(e1, e2, Normal),
-				squaresToMove(e1, f2, Normal),
-				squaresToMove(e1, f1, Normal),
-				WhiteKingSideCastling,
+				squaresToMove(E1, E2, Normal),
+				squaresToMove(E1, F2, Normal),
 			},
 		},
 		{
 			"pos2",
 			pos2,
 			[]Move{
-				squaresToMove(e8, d8, Normal),
-				squaresToMove(e8, d7, Normal),
-				squaresToMove(e8, e7, Normal),
-				squaresToMove(e8, f7, Normal),
-				squaresToMove(e8, f8, Normal),
+				squaresToMove(E8, D8, Normal),
+				squaresToMove(E8, D7, Normal),
+				squaresToMove(E8, E7, Normal),
+				squaresToMove(E8, F7, Normal),
+				squaresToMove(E8, F8, Normal),
 				BlackQueenSideCastling,
 			},
 		},
@@ -214,65 +209,65 @@ func TestGenerateKingMoves(t *testing.T) {
 }
 
 func TestGeneratePawnMoves(t *testing.T) {
-	whiteMove, _ := Fen("2R1b3/pP1PP1p1/8/PpPp1Pq1/4PppP/5n2/P1p3p1/3Q1b1R w - d6 0 1").Parse()
-	blackMove, _ := Fen("2R1b3/pP1PP1p1/8/PpPp1Pq1/4PppP/5n2/P1p3p1/3Q1b1R b - h3 0 1").Parse()
+	whiteMove, _ := Fen("2R1b2k/pP1PP1p1/4K3/PpPp1Pq1/4PppP/5n2/P1p3p1/3Q1b1R w - d6 0 1").Parse()
+	blackMove, _ := Fen("2R1b2k/pP1PP1p1/4K3/PpPp1Pq1/4PppP/5n2/P1p3p1/3Q1b1R b - h3 0 1").Parse()
 
 	tcs := []MoveGenTestCase{
 		{
 			"whiteMove",
 			whiteMove,
 			[]Move{
-				squaresToMove(a2, a3, Normal),
-				squaresToMove(a2, a4, DoublePawnPush),
-				squaresToMove(e4, d5, Capture),
-				squaresToMove(e4, e5, Normal),
-				squaresToMove(h4, h5, Normal),
-				squaresToMove(h4, g5, Capture),
-				squaresToMove(a5, a6, Normal),
-				squaresToMove(c5, c6, Normal),
-				squaresToMove(c5, d6, EnPassant),
-				squaresToMove(f5, f6, Normal),
-				squaresToMove(b7, b8, QueenPromotionNormal),
-				squaresToMove(b7, b8, RookPromotionNormal),
-				squaresToMove(b7, b8, BishopPromotionNormal),
-				squaresToMove(b7, b8, KnightPromotionNormal),
-				squaresToMove(d7, d8, QueenPromotionNormal),
-				squaresToMove(d7, d8, RookPromotionNormal),
-				squaresToMove(d7, d8, BishopPromotionNormal),
-				squaresToMove(d7, d8, KnightPromotionNormal),
-				squaresToMove(d7, e8, QueenPromotionCapture),
-				squaresToMove(d7, e8, BishopPromotionCapture),
-				squaresToMove(d7, e8, KnightPromotionCapture),
-				squaresToMove(d7, e8, RookPromotionCapture),
+				squaresToMove(A2, A3, Normal),
+				squaresToMove(A2, A4, DoublePawnPush),
+				squaresToMove(E4, D5, Capture),
+				squaresToMove(E4, E5, Normal),
+				squaresToMove(H4, H5, Normal),
+				squaresToMove(H4, G5, Capture),
+				squaresToMove(A5, A6, Normal),
+				squaresToMove(C5, C6, Normal),
+				squaresToMove(C5, D6, EnPassant),
+				squaresToMove(F5, F6, Normal),
+				squaresToMove(B7, B8, QueenPromotionNormal),
+				squaresToMove(B7, B8, RookPromotionNormal),
+				squaresToMove(B7, B8, BishopPromotionNormal),
+				squaresToMove(B7, B8, KnightPromotionNormal),
+				squaresToMove(D7, D8, QueenPromotionNormal),
+				squaresToMove(D7, D8, RookPromotionNormal),
+				squaresToMove(D7, D8, BishopPromotionNormal),
+				squaresToMove(D7, D8, KnightPromotionNormal),
+				squaresToMove(D7, E8, QueenPromotionCapture),
+				squaresToMove(D7, E8, BishopPromotionCapture),
+				squaresToMove(D7, E8, KnightPromotionCapture),
+				squaresToMove(D7, E8, RookPromotionCapture),
 			},
 		},
 		{
 			"blackMove",
 			blackMove,
 			[]Move{
-				squaresToMove(c2, c1, QueenPromotionNormal),
-				squaresToMove(c2, c1, RookPromotionNormal),
-				squaresToMove(c2, c1, BishopPromotionNormal),
-				squaresToMove(c2, c1, KnightPromotionNormal),
-				squaresToMove(c2, d1, QueenPromotionCapture),
-				squaresToMove(c2, d1, RookPromotionCapture),
-				squaresToMove(c2, d1, BishopPromotionCapture),
-				squaresToMove(c2, d1, KnightPromotionCapture),
-				squaresToMove(g2, g1, QueenPromotionNormal),
-				squaresToMove(g2, g1, RookPromotionNormal),
-				squaresToMove(g2, g1, BishopPromotionNormal),
-				squaresToMove(g2, g1, KnightPromotionNormal),
-				squaresToMove(g2, h1, QueenPromotionCapture),
-				squaresToMove(g2, h1, RookPromotionCapture),
-				squaresToMove(g2, h1, BishopPromotionCapture),
-				squaresToMove(g2, h1, KnightPromotionCapture),
-				squaresToMove(g4, g3, Normal),
-				squaresToMove(g4, h3, EnPassant),
-				squaresToMove(b5, b4, Normal),
-				squaresToMove(d5, d4, Normal),
-				squaresToMove(d5, e4, Capture),
-				squaresToMove(a7, a6, Normal),
-				squaresToMove(g7, g6, Normal),
+				squaresToMove(C2, C1, QueenPromotionNormal),
+				squaresToMove(C2, C1, RookPromotionNormal),
+				squaresToMove(C2, C1, BishopPromotionNormal),
+				squaresToMove(C2, C1, KnightPromotionNormal),
+				squaresToMove(C2, D1, QueenPromotionCapture),
+				squaresToMove(C2, D1, RookPromotionCapture),
+				squaresToMove(C2, D1, BishopPromotionCapture),
+				squaresToMove(C2, D1, KnightPromotionCapture),
+				squaresToMove(G2, G1, QueenPromotionNormal),
+				squaresToMove(G2, G1, RookPromotionNormal),
+				squaresToMove(G2, G1, BishopPromotionNormal),
+				squaresToMove(G2, G1, KnightPromotionNormal),
+				squaresToMove(G2, H1, QueenPromotionCapture),
+				squaresToMove(G2, H1, RookPromotionCapture),
+				squaresToMove(G2, H1, BishopPromotionCapture),
+				squaresToMove(G2, H1, KnightPromotionCapture),
+				squaresToMove(G4, G3, Normal),
+				squaresToMove(G4, H3, EnPassant),
+				squaresToMove(B5, B4, Normal),
+				squaresToMove(D5, D4, Normal),
+				squaresToMove(D5, E4, Capture),
+				squaresToMove(A7, A6, Normal),
+				squaresToMove(G7, G6, Normal),
 			},
 		},
 	}
@@ -283,3 +278,149 @@ func TestGeneratePawnMoves(t *testing.T) {
 		assert.ElementsMatch(t, tcs[i].expectedMoves, actualMoves)
 	}
 }
+
+func TestGenerateAllMoves(t *testing.T) {
+	startingPosition, _ := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
+	pinnedKnight, _ := Fen("4k3/4r3/8/8/8/8/4N3/4K3 w - - 0 1").Parse()
+	doubleCheck, _ := Fen("4k3/8/8/8/8/3n4/8/r3K1B1 w - - 0 1").Parse()
+	enPassantHorizontalPin, _ := Fen("8/8/8/K2pP2r/8/8/8/4k3 w - d6 0 1").Parse()
+	pinnedBishop, _ := Fen("4k3/8/8/q7/8/8/3B4/4K3 w - - 0 1").Parse()
+	pinnedPawn, _ := Fen("4k3/8/8/8/8/5b2/4P3/3K4 w - - 0 1").Parse()
+	blockCheck, _ := Fen("4k3/4r3/8/8/8/2N5/8/4K3 w - - 0 1").Parse()
+
+	tcs := []MoveGenTestCase{
+		{
+			"startingPosition",
+			startingPosition,
+			[]Move{
+				squaresToMove(A2, A3, Normal),
+				squaresToMove(B2, B3, Normal),
+				squaresToMove(C2, C3, Normal),
+				squaresToMove(D2, D3, Normal),
+				squaresToMove(E2, E3, Normal),
+				squaresToMove(F2, F3, Normal),
+				squaresToMove(G2, G3, Normal),
+				squaresToMove(H2, H3, Normal),
+				squaresToMove(A2, A4, DoublePawnPush),
+				squaresToMove(B2, B4, DoublePawnPush),
+				squaresToMove(C2, C4, DoublePawnPush),
+				squaresToMove(D2, D4, DoublePawnPush),
+				squaresToMove(E2, E4, DoublePawnPush),
+				squaresToMove(F2, F4, DoublePawnPush),
+				squaresToMove(G2, G4, DoublePawnPush),
+				squaresToMove(H2, H4, DoublePawnPush),
+				squaresToMove(B1, A3, Normal),
+				squaresToMove(B1, C3, Normal),
+				squaresToMove(G1, F3, Normal),
+				squaresToMove(G1, H3, Normal),
+			},
+		},
+		{
+			"pinnedKnight",
+			pinnedKnight,
+			[]Move{
+				squaresToMove(E1, D1, Normal),
+				squaresToMove(E1, D2, Normal),
+				squaresToMove(E1, F1, Normal),
+				squaresToMove(E1, F2, Normal),
+			},
+		},
+		{
+			"doubleCheck",
+			doubleCheck,
+			[]Move{
+				squaresToMove(E1, D2, Normal),
+				squaresToMove(E1, E2, Normal),
+			},
+		},
+		{
+			"enPassantHorizontalPin",
+			enPassantHorizontalPin,
+			[]Move{
+				squaresToMove(A5, A4, Normal),
+				squaresToMove(A5, A6, Normal),
+				squaresToMove(A5, B4, Normal),
+				squaresToMove(A5, B5, Normal),
+				squaresToMove(A5, B6, Normal),
+				squaresToMove(E5, E6, Normal),
+			},
+		},
+		{
+			"pinnedBishop",
+			pinnedBishop,
+			[]Move{
+				squaresToMove(E1, D1, Normal),
+				squaresToMove(E1, E2, Normal),
+				squaresToMove(E1, F1, Normal),
+				squaresToMove(E1, F2, Normal),
+				squaresToMove(D2, C3, Normal),
+				squaresToMove(D2, B4, Normal),
+				squaresToMove(D2, A5, Capture),
+			},
+		},
+		{
+			"pinnedPawn",
+			pinnedPawn,
+			[]Move{
+				squaresToMove(D1, C1, Normal),
+				squaresToMove(D1, C2, Normal),
+				squaresToMove(D1, D2, Normal),
+				squaresToMove(D1, E1, Normal),
+				squaresToMove(E2, F3, Capture),
+			},
+		},
+		{
+			"blockCheck",
+			blockCheck,
+			[]Move{
+				squaresToMove(E1, D1, Normal),
+				squaresToMove(E1, D2, Normal),
+				squaresToMove(E1, F1, Normal),
+				squaresToMove(E1, F2, Normal),
+				squaresToMove(C3, E2, Normal),
+				squaresToMove(C3, E4, Normal),
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.tcName, func(t *testing.T) {
+			actualMoves := GenerateAllMoves(tc.cp, 1)
+			assert.Equal(t, len(tc.expectedMoves), len(actualMoves))
+			assert.ElementsMatch(t, tc.expectedMoves, actualMoves)
+		})
+	}
+}
+
+func TestGenerateAllMoves_CheckEvasions(t *testing.T) {
+	castlingThroughCheck, _ := Fen("3rk3/8/8/8/8/8/8/R3K2R w KQ - 0 1").Parse()
+	enPassantCapturesChecker, _ := Fen("8/8/8/2k5/3Pp3/8/8/4K3 b - d3 0 1").Parse()
+
+	castlingMoves := GenerateAllMoves(castlingThroughCheck, 1)
+	assert.Contains(t, castlingMoves, WhiteKingSideCastling)
+	assert.NotContains(t, castlingMoves, WhiteQueenSideCastling)
+
+	evasions := GenerateAllMoves(enPassantCapturesChecker, 1)
+	assert.Contains(t, evasions, squaresToMove(E4, D3, EnPassant))
+	assert.NotContains(t, evasions, squaresToMove(E4, E3, Normal))
+}
+
+func TestGenerateAllMoves_MissingPieces(t *testing.T) {
+	// rights & target are set on parsed positions, fen parsing doesn't accept them without the pieces
+	kingOffHome, _ := Fen("4k3/8/8/8/8/8/8/3K4 w - - 0 1").Parse()
+	kingOffHome.castlingRights[White].kingSide = true
+	rookOffHome, _ := Fen("4k3/8/8/8/8/8/8/R3K3 w - - 0 1").Parse()
+	rookOffHome.castlingRights[White] = CastlingType{kingSide: true, queenSide: true}
+	noPawnBehindTarget, _ := Fen("4k3/8/8/3P4/8/8/8/4K3 w - - 0 1").Parse()
+	noPawnBehindTarget.enPassantTarget = E6
+
+	assert.NotContains(t, GenerateAllMoves(kingOffHome, 1), WhiteKingSideCastling)
+
+	moves := GenerateAllMoves(rookOffHome, 1)
+	assert.NotContains(t, moves, WhiteKingSideCastling)
+	assert.Contains(t, moves, WhiteQueenSideCastling)
+
+	moves = GenerateAllMoves(noPawnBehindTarget, 1)
+	assert.NotContains(t, moves, squaresToMove(D5, E6, EnPassant))
+	assert.Contains(t, moves, squaresToMove(D5, D6, Normal))
+}
@@ -3,70 +3,70 @@ package src
 type Square uint16
 
 const (
-	a1 Square = iota
-	b1
-	c1
-	d1
-	e1
-	f1
-	g1
-	h1
-	a2
-	b2
-	c2
-	d2
-	e2
-	f2
-	g2
-	h2
-	a3
-	b3
-	c3
-	d3
-	e3
-	f3
-	g3
-	h3
-	a4
-	b4
-	c4
-	d4
-	e4
-	f4
-	g4
-	h4
-	a5
-	b5
-	c5
-	d5
-	e5
-	f5
-	g5
-	h5
-	a6
-	b6
-	c6
-	d6
-	e6
-	f6
-	g6
-	h6
-	a7
-	b7
-	c7
-	d7
-	e7
-	f7
-	g7
-	h7
-	a8
-	b8
-	c8
-	d8
-	e8
-	f8
-	g8
-	h8
+	A1 Square = iota
+	B1
+	C1
+	D1
+	E1
+	F1
+	G1
+	H1
+	A2
+	B2
+	C2
+	D2
+	E2
+	F2
+	G2
+	H2
+	A3
+	B3
+	C3
+	D3
+	E3
+	F3
+	G3
+	H3
+	A4
+	B4
+	C4
+	D4
+	E4
+	F4
+	G4
+	H4
+	A5
+	B5
+	C5
+	D5
+	E5
+	F5
+	G5
+	H5
+	A6
+	B6
+	C6
+	D6
+	E6
+	F6
+	G6
+	H6
+	A7
+	B7
+	C7
+	D7
+	E7
+	F7
+	G7
+	H7
+	A8
+	B8
+	C8
+	D8
+	E8
+	F8
+	G8
+	H8
 )
 
 type Move uint16
@@ -100,6 +100,81 @@ const (
 	EnPassant Move = 15 << 12
 )
 
+const moveTypeMask Move = 15 << 12
+
+// Square the move starts from, castling moves only hold their type
+func (move Move) From() Square {
+	return Square(move & 63)
+}
+
+// Square the move ends on, castling moves only hold their type
+func (move Move) To() Square {
+	return Square((move >> 6) & 63)
+}
+
+func (move Move) moveType() Move {
+	return move & moveTypeMask
+}
+
+// From & to squares of the moved piece, king squares for castling
+func (move Move) fromTo() (Square, Square) {
+	if move.isCastling() {
+		kingFrom, kingTo, _, _ := castlingSquares(move.moveType())
+		return kingFrom, kingTo
+	}
+	return move.From(), move.To()
+}
+
+// Move captures a piece, en passant included
+func (move Move) IsCapture() bool {
+	switch move.moveType() {
+	case Capture, KnightPromotionCapture, BishopPromotionCapture, RookPromotionCapture, QueenPromotionCapture, EnPassant:
+		return true
+	default:
+		return false
+	}
+}
+
+func (move Move) isPromotion() bool {
+	mt := move.moveType()
+	return mt >= KnightPromotionNormal && mt <= QueenPromotionCapture
+}
+
+func (move Move) isCastling() bool {
+	mt := move.moveType()
+	return mt >= WhiteKingSideCastling && mt <= BlackQueenSideCastling
+}
+
+// Piece type the pawn is promoted to, 0 if move is not a promotion
+func (move Move) PromotionPieceType() PieceType {
+	switch move.moveType() {
+	case KnightPromotionNormal, KnightPromotionCapture:
+		return Knight
+	case BishopPromotionNormal, BishopPromotionCapture:
+		return Bishop
+	case RookPromotionNormal, RookPromotionCapture:
+		return Rook
+	case QueenPromotionNormal, QueenPromotionCapture:
+		return Queen
+	default:
+		return 0
+	}
+}
+
+// King & rook squares (from, to) for castling move types
+func castlingSquares(moveType Move) (kingFrom, kingTo, rookFrom, rookTo Square) {
+	switch moveType {
+	case WhiteKingSideCastling:
+		return E1, G1, H1, F1
+	case WhiteQueenSideCastling:
+		return E1, C1, A1, D1
+	case BlackKingSideCastling:
+		return E8, G8, H8, F8
+	default:
+		return E8, C8, A8, D8
+	}
+}
+
 // Promotion Piece Type of type Move
 // const (
 // 	KnightPromotion Move = 0 << 12
@@ -118,13 +193,27 @@ const (
 // )
 // const moveTypeMask = Move(3) << 14
 
-// func (sq Square) String() string {
-// 	if sq >= 0 && sq <= 63 {
-// 		return string((sq%8)+97) + string((sq/8)+49)
-// 	} else {
-// 		return "No square"
-// 	}
-// }
+func (move Move) String() string {
+	return move.UCI()
+}
+
+// Move in uci long algebraic notation, e.g. e2e4, e7e8q, e1g1
+func (move Move) UCI() string {
+	from, to := move.fromTo()
+
+	moveRep := from.String() + to.String()
+	switch move.PromotionPieceType() {
+	case Knight:
+		moveRep += "n"
+	case Bishop:
+		moveRep += "b"
+	case Rook:
+		moveRep += "r"
+	case Queen:
+		moveRep += "q"
+	}
+	return moveRep
+}
 
 func squaresToMove(start, end Square, moveType Move) Move {
 	return Move(start+end<<6) + moveType
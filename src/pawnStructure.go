@@ -0,0 +1,128 @@
+package src
+
+// Pawn structure bonuses & penalties in centipawns for the midgame (mg) & the endgame (eg)
+const (
+	doubledPawnMg   = -10
+	doubledPawnEg   = -20
+	isolatedPawnMg  = -10
+	isolatedPawnEg  = -15
+	backwardPawnMg  = -8
+	backwardPawnEg  = -10
+	connectedPawnMg = 8
+	connectedPawnEg = 5
+)
+
+// Passed pawn bonuses indexed by the rank of the pawn from its own side
+var passedPawnMg = [8]int{0, 5, 10, 15, 25, 40, 60, 0}
+var passedPawnEg = [8]int{0, 10, 20, 35, 60, 90, 130, 0}
+
+// Number of entries of the pawn hash table, a power of two
+const pawnHashSize = 1 << 14
+
+// Pawn structure scores of both colors which depend on the pawns alone
+type pawnEntry struct {
+	key    uint64
+	mg, eg int
+	// passed pawns of white & black
+	whitePassed, blackPassed Bitboard
+}
+
+// Pawn structure evaluations keyed by the pawn key of the position
+type pawnHashTable [pawnHashSize]pawnEntry
+
+// Pawn structure entry of the position, evaluated if the table doesn't hold it
+func (pht *pawnHashTable) probe(position Position) pawnEntry {
+	slot := &pht[position.pawnKey&(pawnHashSize-1)]
+	// empty slots have key 0, the key of positions without pawns whose entry is empty as well
+	if slot.key != position.pawnKey {
+		*slot = evaluatePawns(position)
+	}
+	return *slot
+}
+
+// Pawn structure scores of white minus black
+func evaluatePawns(position Position) pawnEntry {
+	whitePawns, blackPawns := position.piecePlacement[White][Pawn], position.piecePlacement[Black][Pawn]
+	whiteMg, whiteEg, whitePassed := evaluatePawnsOf(White, whitePawns, blackPawns)
+	blackMg, blackEg, blackPassed := evaluatePawnsOf(Black, blackPawns, whitePawns)
+
+	return pawnEntry{
+		key:         position.pawnKey,
+		mg:          whiteMg - blackMg,
+		eg:          whiteEg - blackEg,
+		whitePassed: whitePassed,
+		blackPassed: blackPassed,
+	}
+}
+
+// Pawn structure scores of the pawns of the color along with its passed pawns, passed pawns are scored by the caller
+func evaluatePawnsOf(c Color, ours, theirs Bitboard) (mg, eg int, passed Bitboard) {
+	theirAttacks := pawnAttacks(c.Opponent(), theirs)
+	supported := pawnAttacks(c, ours) | ours.shift(east) | ours.shift(west)
+
+	for bb := ours; bb > 0; bb &= bb - 1 {
+		sq := bb.leftmostSignificantSquare()
+		sqBb := sqMask[sq].bitMask
+		front, behind := frontSpan(c, sqBb), frontSpan(c.Opponent(), sqBb)
+
+		// rear pawns of a file are doubled
+		if front&ours != 0 {
+			mg, eg = mg+doubledPawnMg, eg+doubledPawnEg
+		}
+
+		isolated := adjacentFileMasks[sq.File()]&ours == 0
+		if isolated {
+			mg, eg = mg+isolatedPawnMg, eg+isolatedPawnEg
+		}
+
+		// no pawn on the adjacent files can advance to support it & its stop square is attacked
+		supporters := (behind | sqBb).shift(east) | (behind | sqBb).shift(west)
+		if !isolated && supporters&ours == 0 && stopSquare(c, sqBb)&theirAttacks != 0 {
+			mg, eg = mg+backwardPawnMg, eg+backwardPawnEg
+		}
+
+		if sqBb&supported != 0 {
+			mg, eg = mg+connectedPawnMg, eg+connectedPawnEg
+		}
+
+		// no pawn in front on its own & adjacent files can stop it
+		if front&ours == 0 && (front|front.shift(east)|front.shift(west))&theirs == 0 {
+			passed |= sqBb
+		}
+	}
+	return
+}
+
+// Passed pawn scores of the color, scaled by the rank & by the path to promotion being free of pieces
+func evaluatePassedPawns(position Position, c Color, passed Bitboard) (mg, eg int) {
+	for bb := passed; bb > 0; bb &= bb - 1 {
+		sq := bb.leftmostSignificantSquare()
+		r := sq.Rank()
+		if c == Black {
+			r = 7 - r
+		}
+
+		pawnMg, pawnEg := passedPawnMg[r], passedPawnEg[r]
+		if frontSpan(c, sqMask[sq].bitMask)&position.allOccupiedSquares == 0 {
+			pawnMg, pawnEg = pawnMg*3/2, pawnEg*3/2
+		}
+		mg, eg = mg+pawnMg, eg+pawnEg
+	}
+	return
+}
+
+// Squares in front of the set squares on the same file from the point of view of the color
+func frontSpan(c Color, bb Bitboard) Bitboard {
+	if c == White {
+		return bb.northFill() << 8
+	}
+	return bb.southFill() >> 8
+}
+
+// Squares in front of the set squares from the point of view of the color
+func stopSquare(c Color, bb Bitboard) Bitboard {
+	if c == White {
+		return bb << 8
+	}
+	return bb >> 8
+}
@@ -0,0 +1,79 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRankMasks(t *testing.T) {
+	assert.Equal(t, Bitboard(0x0101010101010101), fileMasks[0])
+	assert.Equal(t, Bitboard(0x8080808080808080), fileMasks[7])
+	assert.Equal(t, Bitboard(0xFF), rankMasks[0])
+	assert.Equal(t, Bitboard(0xFF00000000000000), rankMasks[7])
+	assert.Equal(t, fileMasks[1], adjacentFileMasks[0])
+	assert.Equal(t, fileMasks[2]|fileMasks[4], adjacentFileMasks[3])
+	assert.Equal(t, fileMasks[6], adjacentFileMasks[7])
+}
+
+func TestEvaluatePawnsOf(t *testing.T) {
+	// EPTC = Evaluate Pawns Test Cases
+	type EPTC struct {
+		desc           string
+		positionFen    Fen
+		expectedMg     int
+		expectedEg     int
+		expectedPassed Bitboard
+	}
+
+	tcs := []EPTC{
+		{"isolated", "4k3/8/8/8/3P4/8/8/4K3 w - - 0 1", isolatedPawnMg, isolatedPawnEg, sqMask[D4].bitMask},
+		{"doubled", "4k3/8/8/8/3P4/8/3P4/4K3 w - - 0 1", doubledPawnMg + 2*isolatedPawnMg, doubledPawnEg + 2*isolatedPawnEg, sqMask[D4].bitMask},
+		{"phalanx", "4k3/8/8/8/3PP3/8/8/4K3 w - - 0 1", 2 * connectedPawnMg, 2 * connectedPawnEg, sqMask[D4].bitMask | sqMask[E4].bitMask},
+		{"defended", "4k3/8/8/8/3P4/4P3/8/4K3 w - - 0 1", connectedPawnMg, connectedPawnEg, sqMask[D4].bitMask | sqMask[E3].bitMask},
+		{"backward", "4k3/8/8/4p3/2P5/3P4/8/4K3 w - - 0 1", backwardPawnMg + connectedPawnMg, backwardPawnEg + connectedPawnEg, sqMask[C4].bitMask},
+		{"stopped by a pawn on the adjacent file", "4k3/3p4/8/4P3/8/8/8/4K3 w - - 0 1", isolatedPawnMg, isolatedPawnEg, 0},
+		{"passed pawns of black", "4k3/8/8/8/3p4/8/5P2/4K3 b - - 0 1", isolatedPawnMg, isolatedPawnEg, sqMask[D4].bitMask},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, err := tc.positionFen.Parse()
+			assert.NoError(t, err)
+			us := position.activeColor
+			mg, eg, passed := evaluatePawnsOf(us, position.piecePlacement[us][Pawn], position.piecePlacement[us.Opponent()][Pawn])
+			assert.Equal(t, tc.expectedMg, mg)
+			assert.Equal(t, tc.expectedEg, eg)
+			assert.Equal(t, tc.expectedPassed, passed)
+		})
+	}
+}
+
+func TestEvaluatePassedPawns(t *testing.T) {
+	free, _ := Fen("k7/8/4P3/8/8/4p3/8/K7 w - - 0 1").Parse()
+	mg, eg := evaluatePassedPawns(free, White, sqMask[E6].bitMask)
+	assert.Equal(t, passedPawnMg[5]*3/2, mg)
+	assert.Equal(t, passedPawnEg[5]*3/2, eg)
+	mg, eg = evaluatePassedPawns(free, Black, sqMask[E3].bitMask)
+	assert.Equal(t, passedPawnMg[5]*3/2, mg)
+	assert.Equal(t, passedPawnEg[5]*3/2, eg)
+
+	blocked, _ := Fen("8/4k3/4P3/8/8/4p3/4K3/8 w - - 0 1").Parse()
+	mg, eg = evaluatePassedPawns(blocked, White, sqMask[E6].bitMask)
+	assert.Equal(t, passedPawnMg[5], mg)
+	assert.Equal(t, passedPawnEg[5], eg)
+}
+
+func TestPawnHashTable(t *testing.T) {
+	position, _ := Fen("r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10").Parse()
+	pht := &pawnHashTable{}
+
+	entry := pht.probe(position)
+	assert.Equal(t, evaluatePawns(position), entry)
+	assert.Equal(t, position.pawnKey, pht[position.pawnKey&(pawnHashSize-1)].key)
+
+	// entry is reused by positions with the same pawns
+	move, _ := position.ParseUCIMove("f3h4")
+	position.MakeMove(move)
+	assert.Equal(t, entry, pht.probe(position))
+}
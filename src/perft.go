@@ -0,0 +1,45 @@
+package src
+
+// https://www.chessprogramming.org/Perft
+// Counts leaf nodes of the legal move tree up to the depth
+func Perft(position Position, depth int) uint64 {
+	if depth <= 0 {
+		return 1
+	}
+	return position.perft(depth)
+}
+
+// Counts leaf nodes up to the depth for every legal move from the position
+func Divide(position Position, depth int) map[Move]uint64 {
+	divide := make(map[Move]uint64)
+	if depth <= 0 {
+		return divide
+	}
+
+	for _, move := range GenerateAllMoves(position, depth) {
+		undo := position.MakeMove(move)
+		divide[move] = position.perft(depth - 1)
+		position.UnmakeMove(undo)
+	}
+	return divide
+}
+
+func (position *Position) perft(depth int) uint64 {
+	if depth == 0 {
+		return 1
+	}
+	moveList := GenerateAllMoves(*position, depth)
+
+	// bulk counting at the last ply
+	if depth == 1 {
+		return uint64(len(moveList))
+	}
+
+	nodes := uint64(0)
+	for _, move := range moveList {
+		undo := position.MakeMove(move)
+		nodes += position.perft(depth - 1)
+		position.UnmakeMove(undo)
+	}
+	return nodes
+}
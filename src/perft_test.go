@@ -0,0 +1,110 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// https://www.chessprogramming.org/Perft_Results
+func TestPerft(t *testing.T) {
+	type PerftTestCase struct {
+		desc        string
+		positionFen Fen
+		depth       int
+		nodes       uint64
+		deep        bool // skipped with -short
+	}
+
+	startingPosition := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	kiwipete := Fen("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	position3 := Fen("8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1")
+	position4 := Fen("r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1")
+	position4Mirrored := Fen("r2q1rk1/pP1p2pp/Q4n2/bbp1p3/Np6/1B3NBn/pPPP1PPP/R3K2R b KQ - 0 1")
+	position5 := Fen("rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8")
+	position6 := Fen("r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10")
+
+	tcs := []PerftTestCase{
+		{"startingPosition", startingPosition, 1, 20, false},
+		{"startingPosition", startingPosition, 2, 400, false},
+		{"startingPosition", startingPosition, 3, 8902, false},
+		{"startingPosition", startingPosition, 4, 197281, false},
+		{"startingPosition", startingPosition, 5, 4865609, true},
+		{"kiwipete", kiwipete, 1, 48, false},
+		{"kiwipete", kiwipete, 2, 2039, false},
+		{"kiwipete", kiwipete, 3, 97862, false},
+		{"kiwipete", kiwipete, 4, 4085603, true},
+		{"position3", position3, 1, 14, false},
+		{"position3", position3, 2, 191, false},
+		{"position3", position3, 3, 2812, false},
+		{"position3", position3, 4, 43238, false},
+		{"position3", position3, 5, 674624, true},
+		{"position4", position4, 1, 6, false},
+		{"position4", position4, 2, 264, false},
+		{"position4", position4, 3, 9467, false},
+		{"position4", position4, 4, 422333, true},
+		{"position4Mirrored", position4Mirrored, 1, 6, false},
+		{"position4Mirrored", position4Mirrored, 2, 264, false},
+		{"position4Mirrored", position4Mirrored, 3, 9467, false},
+		{"position4Mirrored", position4Mirrored, 4, 422333, true},
+		{"position5", position5, 1, 44, false},
+		{"position5", position5, 2, 1486, false},
+		{"position5", position5, 3, 62379, false},
+		{"position5", position5, 4, 2103487, true},
+		{"position6", position6, 1, 46, false},
+		{"position6", position6, 2, 2079, false},
+		{"position6", position6, 3, 89890, false},
+		{"position6", position6, 4, 3894594, true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.deep && testing.Short() {
+				t.Skipf("skipping depth %d in short mode", tc.depth)
+			}
+			position, err := tc.positionFen.Parse()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.nodes, Perft(position, tc.depth), "depth %d", tc.depth)
+		})
+	}
+}
+
+func TestDivide(t *testing.T) {
+	position, _ := Fen("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1").Parse()
+
+	divide := Divide(position, 2)
+	assert.Len(t, divide, 48)
+
+	nodes := uint64(0)
+	for _, n := range divide {
+		nodes += n
+	}
+	assert.Equal(t, uint64(2039), nodes)
+	assert.Equal(t, uint64(43), divide[WhiteKingSideCastling])
+	assert.Equal(t, uint64(36), divide[squaresToMove(E2, A6, Capture)])
+
+	// Position passed to Divide is left unchanged
+	expectedPosition, _ := Fen("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1").Parse()
+	assert.Equal(t, expectedPosition, position)
+}
+
+func BenchmarkPerft(b *testing.B) {
+	benchmarks := []struct {
+		name        string
+		positionFen Fen
+		depth       int
+	}{
+		{"startingPosition", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 4},
+		{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 3},
+	}
+
+	for _, bm := range benchmarks {
+		position, _ := bm.positionFen.Parse()
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Perft(position, bm.depth)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package src
 
+import "strings"
+
 type PieceType int
 
 const (
@@ -93,3 +95,52 @@ func (pt PieceType) PieceRep(c Color) string {
 		return " "
 	}
 }
+
+// Piece letter used in fen, uppercase for White & lowercase for Black
+func (pt PieceType) fenRep(c Color) string {
+	var rep string
+	switch pt {
+	case Pawn:
+		rep = "p"
+	case Knight:
+		rep = "n"
+	case Bishop:
+		rep = "b"
+	case Rook:
+		rep = "r"
+	case Queen:
+		rep = "q"
+	case King:
+		rep = "k"
+	default:
+		return ""
+	}
+
+	if c == White {
+		return strings.ToUpper(rep)
+	}
+	return rep
+}
+
+// Piece of a color, packed as color << 3 | piece type
+type Piece uint8
+
+// Piece on empty squares
+const NoPiece Piece = 0
+
+func NewPiece(c Color, pt PieceType) Piece {
+	return Piece(c)<<3 | Piece(pt)
+}
+
+func (p Piece) Color() Color {
+	return Color(p >> 3)
+}
+
+func (p Piece) Type() PieceType {
+	return PieceType(p & 7)
+}
+
+// Piece letter used in fen, empty for NoPiece
+func (p Piece) String() string {
+	return p.Type().fenRep(p.Color())
+}
@@ -4,39 +4,46 @@ import (
 	"strconv"
 )
 
-type Color bool
+// Color of the pieces & the side to move, indexes the color wise arrays
+type Color uint8
 
 const (
-	White Color = true
-	Black Color = false
+	White Color = iota
+	Black
+	TotalColors
 )
 
 func (c Color) String() string {
 	switch c {
-	case true:
+	case White:
 		return "White"
-	case false:
+	case Black:
 		return "Black"
 	default:
 		return "No Color"
 	}
 }
 
+// Color of the other side
+func (c Color) Opponent() Color {
+	return c ^ 1
+}
+
 type CastlingType struct {
 	kingSide  bool
 	queenSide bool
 }
 
-type CastlingRights map[Color]CastlingType
+type CastlingRights [TotalColors]CastlingType
 
 func (cr CastlingRights) String() string {
 	crRep := "\n"
-	for k, v := range cr {
+	for c, v := range cr {
 		if v.kingSide {
-			crRep += "  - " + k.String() + " King Side Castling available\n"
+			crRep += "  - " + Color(c).String() + " King Side Castling available\n"
 		}
 		if v.queenSide {
-			crRep += "  - " + k.String() + " Queen Side Castling available\n"
+			crRep += "  - " + Color(c).String() + " Queen Side Castling available\n"
 		}
 	}
 	return crRep
@@ -53,10 +60,17 @@ type Position struct {
 	halfMoveClock   uint16
 	fullMoveNumber  uint16
 
+	// zobrist key, updated incrementally while making moves
+	zobristKey uint64
+	// zobrist key of the pawns only
+	pawnKey uint64
+
+	// piece on every square, updated along with the piece placement
+	mailbox [64]Piece
+
 	// auxiliary information
 	occupiedSquaresColorWise OccupiedSquaresColorWise
 	allOccupiedSquares       Bitboard
-	// mailbox                  [64]PieceType
 
 	// auxiliary information for checks and legal moves
 	ourKingDangerSquares Bitboard
@@ -65,17 +79,23 @@ type Position struct {
 	pushMask             Bitboard
 
 	// Pinned pieces
+	pinnedPieces []PinnedPiece
 }
 
 type KingChecker struct {
 	pieceType PieceType
 	bitboard  Bitboard
+	square    Square
+}
+
+type PinnedPiece struct {
+	bitboard Bitboard
+	pinRay   Bitboard // squares between king & pinner including pinner
 }
 
 func (position Position) generateAuxiliaryInfo() Position {
 	// calculating all occupied squares color wise
-	// updatedPosition := position
-	occupiedSqauresColorWise := make(OccupiedSquaresColorWise)
+	var occupiedSqauresColorWise OccupiedSquaresColorWise
 
 	for color, pieceBitboard := range position.piecePlacement {
 		for i := 0; i < len(pieceBitboard); i++ {
@@ -99,6 +119,9 @@ func (position Position) generateAuxiliaryInfo() Position {
 		position.captureMask, position.pushMask = Bitboard(0), Bitboard(0)
 	}
 
+	// calculating pinned pieces & their pin rays
+	position.pinnedPieces = position.calculateAbsolutePinnedPieces()
+
 	return position
 }
 
@@ -113,7 +136,7 @@ func (position Position) calculateOurKingDangerSquares() (Bitboard, []KingChecke
 		usKDSBK = our King Danger Squares by opponent King
 		kCBQ = king checker by Queen
 	*/
-	us, opp := position.activeColor, !position.activeColor
+	us, opp := position.activeColor, position.activeColor.Opponent()
 	KBb := position.piecePlacement[us][King]
 	usOS, oppOS := position.occupiedSquaresColorWise[us], position.occupiedSquaresColorWise[opp]
 	usOSMK := usOS - KBb
@@ -125,52 +148,145 @@ func (position Position) calculateOurKingDangerSquares() (Bitboard, []KingChecke
 	for p := Pawn; p < TotalPieceTypes; p++ {
 		usKDSBP, kC := p.attackBbMP(opp, oppPP[p], KBb, usOSMK, oppOS)
 		usKDS |= usKDSBP
-		if kC.bitboard != 0 {
-			kCs = append(kCs, kC)
-		}
+		kCs = append(kCs, kC...)
 	}
 
 	return usKDS, kCs
 }
 
+// King checkers of the side which is not to move
+func (position Position) opponentKingCheckers() []KingChecker {
+	position.activeColor = position.activeColor.Opponent()
+	_, kingCheckers := position.calculateOurKingDangerSquares()
+	return kingCheckers
+}
+
 // Calculate attacking squares for every piece based on Bitboard
 // attachBBMP = Bitboard of attacking squares by multiple piece of same type
-func (pt PieceType) attackBbMP(color Color, pBB, kBb, usOS, oppOC Bitboard) (Bitboard, KingChecker) {
+func (pt PieceType) attackBbMP(color Color, pBB, kBb, usOS, oppOC Bitboard) (Bitboard, []KingChecker) {
 	attackBb := Bitboard(0)
-	kingChecker := KingChecker{}
+	kingCheckers := []KingChecker{}
 
 	for ; pBB > 0; pBB &= pBB - 1 {
 		// position of piece: 0-63
 		sq := pBB.leftmostSignificantSquare()
 
 		// Calculating attacking squares of single piece including our own pieces
-		attackBb |= pt.attackBbSP(color, sq, usOS, oppOC)
-		if attackBb&kBb != 0 {
-			kingChecker.pieceType = pt
-			kingChecker.bitboard = Bitboard(1 << sq)
+		attackBbSP := pt.attackBbSP(color, sq, usOS, oppOC)
+		if attackBbSP&kBb != 0 {
+			kingCheckers = append(kingCheckers, KingChecker{
+				pieceType: pt,
+				bitboard:  Bitboard(1 << sq),
+				square:    sq,
+			})
 		}
+		attackBb |= attackBbSP
 	}
 
-	return attackBb, kingChecker
+	return attackBb, kingCheckers
 }
 
 func (position Position) calculateCapturePushMask() (Bitboard, Bitboard) {
-	// kBb := position.piecePlacement[position.activeColor][King]
+	kSq := position.piecePlacement[position.activeColor][King].leftmostSignificantSquare()
 	checkingPiece := position.kingCheckers[0].pieceType
 
+	// checking piece can be captured
 	captureMask := position.kingCheckers[0].bitboard
 
+	// check by a slider can be blocked by moving a piece between king & checking piece
+	// check by a knight or pawn can't be blocked
 	pushMask := Bitboard(0)
 	switch checkingPiece {
-	case Rook:
-
+	case Rook, Bishop, Queen:
+		pushMask = squaresBetween(kSq, position.kingCheckers[0].square)
 	}
 	return captureMask, pushMask
 }
 
 // https://en.wikipedia.org/wiki/Pin_(chess)#Absolute_pin
-func (position Position) calculateAbsolutePinnedPieces() {
+func (position Position) calculateAbsolutePinnedPieces() []PinnedPiece {
+	/*
+		kBb = King Bitboard
+		usOS = our occupied squares
+		oppPP = opponent Piece Placement
+		kA = king attacks along the slider
+		xA = x-ray king attacks along the slider through our pieces
+	*/
+	us, opp := position.activeColor, position.activeColor.Opponent()
+	kBb := position.piecePlacement[us][King]
+	pinnedPieces := []PinnedPiece{}
+	if kBb == 0 {
+		return pinnedPieces
+	}
+
+	kSq := kBb.leftmostSignificantSquare()
+	usOS := position.occupiedSquaresColorWise[us]
+	occ := position.allOccupiedSquares
+	oppPP := position.piecePlacement[opp]
+
+	for slider := file; slider <= antiDiagonal; slider++ {
+		// opponent pieces which can pin along the slider
+		var pinners Bitboard
+		if slider == file || slider == rank {
+			pinners = oppPP[Rook] | oppPP[Queen]
+		} else {
+			pinners = oppPP[Bishop] | oppPP[Queen]
+		}
+		if pinners&sqMask[kSq].sliderMaskEx[slider] == 0 {
+			continue
+		}
+
+		kA := slider.sliderAttacks(kSq, occ)
+		xA := slider.sliderAttacks(kSq, occ&^(kA&usOS))
+
+		// pinners are only visible to the king through one of our pieces
+		for pinners &= xA &^ kA; pinners > 0; pinners &= pinners - 1 {
+			pSq := pinners.leftmostSignificantSquare()
+			between := squaresBetween(kSq, pSq)
+			pinnedPieces = append(pinnedPieces, PinnedPiece{
+				bitboard: between & usOS,
+				pinRay:   between | sqMask[pSq].bitMask,
+			})
+		}
+	}
+
+	return pinnedPieces
+}
 
+// Returns the squares where piece on the square can move without exposing our king
+func (position Position) pinRay(sq Square) Bitboard {
+	for _, pinnedPiece := range position.pinnedPieces {
+		if pinnedPiece.bitboard == sqMask[sq].bitMask {
+			return pinnedPiece.pinRay
+		}
+	}
+	return Bitboard(0xFFFFFFFFFFFFFFFF)
+}
+
+// Checks whether en passant capture exposes our king,
+// both pawns leave the rank (or diagonal) between our king & an opponent slider
+func (position Position) enPassantExposesKing(move Move) bool {
+	us, opp := position.activeColor, position.activeColor.Opponent()
+	kBb := position.piecePlacement[us][King]
+	if kBb == 0 {
+		return false
+	}
+	kSq := kBb.leftmostSignificantSquare()
+
+	fromBb, toBb := sqMask[move.From()].bitMask, sqMask[move.To()].bitMask
+	var capturedBb Bitboard
+	if us == White {
+		capturedBb = toBb.shift(south)
+	} else {
+		capturedBb = toBb.shift(north)
+	}
+
+	// occupied squares after en passant capture
+	occ := (position.allOccupiedSquares &^ fromBb &^ capturedBb) | toBb
+	oppPP := position.piecePlacement[opp]
+
+	return rank.sliderAttacks(kSq, occ)&(oppPP[Rook]|oppPP[Queen]) != 0 ||
+		bishopAttacks(kSq, occ)&(oppPP[Bishop]|oppPP[Queen]) != 0
 }
 
 // utility toString functions
@@ -179,7 +295,7 @@ func (position Position) String() string {
 	positionRep += position.piecePlacement.String()
 	positionRep += "\nActive color: " + position.activeColor.String()
 	positionRep += "\nCastlingRights: " + position.castlingRights.String()
-	// positionRep += "\nEn Passant target: " + position.enPassantTarget.String()
+	positionRep += "\nEn Passant target: " + position.enPassantTarget.String()
 	positionRep += "\nHalf Move Clock: " + strconv.FormatInt(int64(position.halfMoveClock), 10)
 	positionRep += "\nFull Move Number: " + strconv.FormatInt(int64(position.fullMoveNumber), 10) + "\n"
 
@@ -210,130 +326,200 @@ func generateBoardString(s [][]string) (a [64]string) {
 	return
 }
 
-// // assuming legal moves
-// func (oldPosition Position) makeMove(move Move) (Position, error) {
-// 	us, them := oldPosition.activeColor, !oldPosition.activeColor
-// 	var ourPieces = oldPosition.piecePlacement[us]
-// 	var theirPieces = oldPosition.piecePlacement[them]
-
-// 	var _ Square = Square(move & 63)
-// 	var moveTo Square = Square((move >> 6) & 63)
-
-// 	var moveFromBB Bitboard = 1 << (move & 63)
-// 	var moveToBB Bitboard = 1 << ((move >> 6) & 63)
-
-// 	moveType := move & moveTypeMask
-
-// 	var updatedPiecePlacement PiecePlacementR = oldPosition.piecePlacement
-// 	var updatedOurPieces = oldPosition.piecePlacement[us]
-// 	var updatedTheirPieces = oldPosition.piecePlacement[them]
-
-// 	var updatedCastlingRights = oldPosition.castlingRights
-
-// 	var updatedEnPassantTarget Square
-
-// 	switch moveType {
-// 	case Move(Normal):
-// 		// determining which piece is moved
-// 		var movedPiece PieceType
-// 		if moveFromBB&ourPieces[Pawn] != 0 {
-// 			movedPiece = Pawn
-// 		} else if moveFromBB&ourPieces[Knight] != 0 {
-// 			movedPiece = Knight
-// 		} else if moveFromBB&ourPieces[Bishop] != 0 {
-// 			movedPiece = Bishop
-// 		} else if moveFromBB&ourPieces[Rook] != 0 {
-// 			movedPiece = Rook
-// 		} else if moveFromBB&ourPieces[Queen] != 0 {
-// 			movedPiece = Queen
-// 		} else if moveFromBB&ourPieces[King] != 0 {
-// 			movedPiece = King
-// 		}
-
-// 		// reset castling rights in case rook or king has moved from home square
-
-// 		// set enpassant target in case pawn has moved 2 squares
-
-// 		// changing position of the moved piece
-// 		updatedOurPieces[movedPiece] = ourPieces[movedPiece] - moveFromBB + moveToBB
-// 		updatedPiecePlacement[us] = updatedOurPieces
-// 		// removing opponent piece in case it is present
-// 		updatedTheirPieces[Pawn] &= ^(moveToBB)
-// 		updatedTheirPieces[Knight] &= ^(moveToBB)
-// 		updatedTheirPieces[Bishop] &= ^(moveToBB)
-// 		updatedTheirPieces[Rook] &= ^(moveToBB)
-// 		updatedTheirPieces[Queen] &= ^(moveToBB)
-// 		updatedPiecePlacement[them] = updatedTheirPieces
-
-// 	case Move(Promotion):
-// 		var promotionType Move = move & promotionPieceTypeMask
-
-// 		switch promotionType {
-// 		case Move(QueenPromotion):
-// 			updatedOurPieces[Queen] = ourPieces[Queen] + moveToBB
-// 		case Move(RookPromotion):
-// 			updatedOurPieces[Rook] = ourPieces[Rook] + moveToBB
-// 		case Move(BishopPromotion):
-// 			updatedOurPieces[Bishop] = ourPieces[Bishop] + moveToBB
-// 		case Move(KnightPromotion):
-// 			updatedOurPieces[Knight] = ourPieces[Knight] + moveToBB
-// 		}
-// 		updatedOurPieces[Pawn] = ourPieces[Pawn] - moveFromBB
-// 		updatedPiecePlacement[us] = updatedOurPieces
-
-// 		updatedTheirPieces[Knight] &= ^(moveToBB)
-// 		updatedTheirPieces[Bishop] &= ^(moveToBB)
-// 		updatedTheirPieces[Rook] &= ^(moveToBB)
-// 		updatedTheirPieces[Queen] &= ^(moveToBB)
-
-// 		updatedPiecePlacement[them] = updatedTheirPieces
-// 	case Move(CastlingM):
-// 		updatedOurPieces[King] = moveToBB
-// 		switch moveTo {
-// 		case g1:
-// 			updatedOurPieces[Rook] -= (1 << h1) + (1 << f1)
-// 			updatedCastlingRights[White] = CastlingType{}
-// 		case c1:
-// 			updatedOurPieces[Rook] -= (1 << a1) + (1 << c1)
-// 			updatedCastlingRights[White] = CastlingType{}
-// 		case g8:
-// 			updatedOurPieces[Rook] -= (1 << h8) + (1 << f8)
-// 			updatedCastlingRights[Black] = CastlingType{}
-// 		case c8:
-// 			updatedOurPieces[Rook] -= (1 << a8) + (1 << c8)
-// 			updatedCastlingRights[Black] = CastlingType{}
-// 		}
-// 		updatedPiecePlacement[us] = updatedOurPieces
-// 	case Move(EnPassant):
-// 		updatedOurPieces[Pawn] = ourPieces[Pawn] - moveFromBB + moveToBB
-// 		if oldPosition.activeColor == White {
-// 			updatedTheirPieces[Pawn] = theirPieces[Pawn] - (moveFromBB >> 8)
-// 		} else {
-// 			updatedTheirPieces[Pawn] = theirPieces[Pawn] - (moveFromBB << 8)
-// 		}
-// 		updatedEnPassantTarget = 64
-// 	}
-
-// 	// only update full move number in case black has made a move
-// 	var updatedFullMoveNumber uint16 = oldPosition.fullMoveNumber
-// 	if oldPosition.activeColor == Black {
-// 		updatedFullMoveNumber += 1
-// 	}
-
-// 	// update auxiliary information & return
-// 	return Position{
-// 		piecePlacement:  updatedPiecePlacement,
-// 		activeColor:     !oldPosition.activeColor, // reverse the color White <-> Black
-// 		castlingRights:  updatedCastlingRights,
-// 		enPassantTarget: updatedEnPassantTarget,
-// 		halfMoveClock:   oldPosition.halfMoveClock + 1, // update halfMoveClock since a move is made
-// 		fullMoveNumber:  updatedFullMoveNumber,
-// 	}.updateAuxiliaryInfo(), nil
-// }
-
-// func (position Position) updateAuxiliaryInfo() Position {
-// 	position.occupiedSquaresColorWise[White] = position.piecePlacement[White][Pawn] | position.piecePlacement[White][Knight] | position.piecePlacement[White][Bishop] | position.piecePlacement[White][Rook] | position.piecePlacement[White][Queen] | position.piecePlacement[White][King]
-// 	position.occupiedSquaresColorWise[Black] = position.piecePlacement[Black][Pawn] | position.piecePlacement[Black][Knight] | position.piecePlacement[Black][Bishop] | position.piecePlacement[Black][Rook] | position.piecePlacement[Black][Queen] | position.piecePlacement[Black][King]
-
-// 	return position
-// }
+// Information which can't be recovered from the move itself while unmaking it
+type Undo struct {
+	move            Move
+	capturedPiece   PieceType
+	castlingRights  CastlingRights
+	enPassantTarget Square
+	halfMoveClock   uint16
+	zobristKey      uint64
+}
+
+// Makes a legal move on the position in place & returns the record required to unmake it.
+func (position *Position) MakeMove(move Move) Undo {
+	us, opp := position.activeColor, position.activeColor.Opponent()
+	from, to := move.From(), move.To()
+	moveType := move.moveType()
+
+	undo := Undo{
+		move:            move,
+		castlingRights:  position.castlingRights,
+		enPassantTarget: position.enPassantTarget,
+		halfMoveClock:   position.halfMoveClock,
+		zobristKey:      position.zobristKey,
+	}
+
+	// castling rights & en passant are added back to the key after the move
+	position.zobristKey ^= position.zobristCastling() ^ position.zobristEnPassant()
+
+	position.enPassantTarget = 64
+	position.halfMoveClock++
+
+	switch {
+	case move.isCastling():
+		kingFrom, kingTo, rookFrom, rookTo := castlingSquares(moveType)
+		position.togglePiece(us, King, sqMask[kingFrom].bitMask|sqMask[kingTo].bitMask)
+		position.togglePiece(us, Rook, sqMask[rookFrom].bitMask|sqMask[rookTo].bitMask)
+		position.castlingRights[us] = CastlingType{}
+	case moveType == EnPassant:
+		capturedSq := enPassantCapturedSquare(us, to)
+		position.togglePiece(opp, Pawn, sqMask[capturedSq].bitMask)
+		position.togglePiece(us, Pawn, sqMask[from].bitMask|sqMask[to].bitMask)
+		undo.capturedPiece = Pawn
+		position.halfMoveClock = 0
+	default:
+		movedPiece := position.pieceTypeOn(us, from)
+		if move.IsCapture() {
+			undo.capturedPiece = position.pieceTypeOn(opp, to)
+			position.togglePiece(opp, undo.capturedPiece, sqMask[to].bitMask)
+		}
+
+		if move.isPromotion() {
+			position.togglePiece(us, Pawn, sqMask[from].bitMask)
+			position.togglePiece(us, move.PromotionPieceType(), sqMask[to].bitMask)
+		} else {
+			position.togglePiece(us, movedPiece, sqMask[from].bitMask|sqMask[to].bitMask)
+		}
+
+		if moveType == DoublePawnPush {
+			position.enPassantTarget = (from + to) / 2
+		}
+		if movedPiece == Pawn || move.IsCapture() {
+			position.halfMoveClock = 0
+		}
+
+		// king or rook leaving its home square, or rook captured on its home square
+		position.removeCastlingRights(from)
+		position.removeCastlingRights(to)
+	}
+
+	// full move number is incremented after black's move
+	if us == Black {
+		position.fullMoveNumber++
+	}
+	position.activeColor = opp
+	position.zobristKey ^= position.zobristCastling() ^ position.zobristEnPassant() ^ polyglotRandoms[zobristTurnOffset]
+
+	*position = position.generateAuxiliaryInfo()
+
+	return undo
+}
+
+// Restores the position to the state before the move recorded in undo was made
+func (position *Position) UnmakeMove(undo Undo) {
+	// color which made the move
+	us, opp := position.activeColor.Opponent(), position.activeColor
+	move := undo.move
+	from, to := move.From(), move.To()
+
+	switch {
+	case move.isCastling():
+		kingFrom, kingTo, rookFrom, rookTo := castlingSquares(move.moveType())
+		position.togglePiece(us, King, sqMask[kingFrom].bitMask|sqMask[kingTo].bitMask)
+		position.togglePiece(us, Rook, sqMask[rookFrom].bitMask|sqMask[rookTo].bitMask)
+	case move.moveType() == EnPassant:
+		position.togglePiece(us, Pawn, sqMask[from].bitMask|sqMask[to].bitMask)
+		position.togglePiece(opp, Pawn, sqMask[enPassantCapturedSquare(us, to)].bitMask)
+	default:
+		if move.isPromotion() {
+			position.togglePiece(us, move.PromotionPieceType(), sqMask[to].bitMask)
+			position.togglePiece(us, Pawn, sqMask[from].bitMask)
+		} else {
+			position.togglePiece(us, position.pieceTypeOn(us, to), sqMask[from].bitMask|sqMask[to].bitMask)
+		}
+
+		if move.IsCapture() {
+			position.togglePiece(opp, undo.capturedPiece, sqMask[to].bitMask)
+		}
+	}
+
+	position.castlingRights = undo.castlingRights
+	position.enPassantTarget = undo.enPassantTarget
+	position.halfMoveClock = undo.halfMoveClock
+	position.zobristKey = undo.zobristKey
+	if us == Black {
+		position.fullMoveNumber--
+	}
+	position.activeColor = us
+
+	*position = position.generateAuxiliaryInfo()
+}
+
+// Adds or removes the piece on squares set in the bitboard
+func (position *Position) togglePiece(c Color, pt PieceType, bb Bitboard) {
+	position.piecePlacement[c][pt] ^= bb
+
+	piece := NewPiece(c, pt)
+	for sqs := bb; sqs > 0; sqs &= sqs - 1 {
+		sq := sqs.leftmostSignificantSquare()
+		if position.mailbox[sq] == piece {
+			position.mailbox[sq] = NoPiece
+		} else {
+			position.mailbox[sq] = piece
+		}
+	}
+
+	key := zobristPieces(c, pt, bb)
+	position.zobristKey ^= key
+	if pt == Pawn {
+		position.pawnKey ^= key
+	}
+}
+
+// Piece on the square, NoPiece if the square is empty
+func (position Position) PieceAt(sq Square) Piece {
+	return position.mailbox[sq]
+}
+
+// Piece type of the color on the square, 0 if the square is empty or holds a piece of the other color
+func (position Position) pieceTypeOn(c Color, sq Square) PieceType {
+	if piece := position.mailbox[sq]; piece != NoPiece && piece.Color() == c {
+		return piece.Type()
+	}
+	return 0
+}
+
+// Piece on every square of the piece placement
+func (pp PiecePlacement) mailbox() (mailbox [64]Piece) {
+	for c := White; c < TotalColors; c++ {
+		for pt := Pawn; pt < TotalPieceTypes; pt++ {
+			for bb := pp[c][pt]; bb > 0; bb &= bb - 1 {
+				mailbox[bb.leftmostSignificantSquare()] = NewPiece(c, pt)
+			}
+		}
+	}
+	return mailbox
+}
+
+// Square of the pawn captured by en passant on the target square
+func enPassantCapturedSquare(us Color, ep Square) Square {
+	if us == White {
+		return ep - 8
+	}
+	return ep + 8
+}
+
+// Removes castling rights affected by a piece moving from or to the square
+func (position *Position) removeCastlingRights(sq Square) {
+	white, black := position.castlingRights[White], position.castlingRights[Black]
+
+	switch sq {
+	case A1:
+		white.queenSide = false
+	case E1:
+		white = CastlingType{}
+	case H1:
+		white.kingSide = false
+	case A8:
+		black.queenSide = false
+	case E8:
+		black = CastlingType{}
+	case H8:
+		black.kingSide = false
+	default:
+		return
+	}
+
+	position.castlingRights[White], position.castlingRights[Black] = white, black
+}
@@ -0,0 +1,86 @@
+package src
+
+// Read only queries of the position, none of them changes it
+
+// Color to make the next move
+func (position Position) SideToMove() Color {
+	return position.activeColor
+}
+
+// Castling rights of both colors, indexed by Color
+func (position Position) CastlingRights() CastlingRights {
+	return position.castlingRights
+}
+
+// Square behind a pawn which just made a double push, false if there is none
+func (position Position) EnPassantSquare() (Square, bool) {
+	if position.enPassantTarget >= 64 {
+		return 0, false
+	}
+	return position.enPassantTarget, true
+}
+
+// Half moves since the last capture or pawn move
+func (position Position) HalfMoveClock() int {
+	return int(position.halfMoveClock)
+}
+
+// Starts at 1 & is incremented after every move of black
+func (position Position) FullMoveNumber() int {
+	return int(position.fullMoveNumber)
+}
+
+// Squares of the pieces of the color & type
+func (position Position) Pieces(c Color, pt PieceType) Bitboard {
+	return position.piecePlacement[c][pt]
+}
+
+// Squares occupied by the pieces of the color
+func (position Position) Occupancy(c Color) Bitboard {
+	return position.occupiedSquaresColorWise[c]
+}
+
+// Squares occupied by the pieces of both colors
+func (position Position) Occupied() Bitboard {
+	return position.allOccupiedSquares
+}
+
+// Square of the king of the color
+func (position Position) KingSquare(c Color) Square {
+	return position.piecePlacement[c][King].leftmostSignificantSquare()
+}
+
+// Side to move is in check
+func (position Position) InCheck() bool {
+	return len(position.kingCheckers) > 0
+}
+
+// Squares of the pieces giving check to the side to move, empty if not in check
+func (position Position) Checkers() (checkers Bitboard) {
+	for _, kc := range position.kingCheckers {
+		checkers |= kc.bitboard
+	}
+	return checkers
+}
+
+// Piece type captured by the move, 0 if move is not a capture
+func (position Position) CapturedPiece(move Move) PieceType {
+	switch move.moveType() {
+	case EnPassant:
+		return Pawn
+	case Capture, KnightPromotionCapture, BishopPromotionCapture, RookPromotionCapture, QueenPromotionCapture:
+		return position.pieceTypeOn(position.activeColor.Opponent(), move.To())
+	default:
+		return 0
+	}
+}
+
+// King side castling is available
+func (ct CastlingType) KingSide() bool {
+	return ct.kingSide
+}
+
+// Queen side castling is available
+func (ct CastlingType) QueenSide() bool {
+	return ct.queenSide
+}
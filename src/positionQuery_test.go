@@ -0,0 +1,72 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionQueries(t *testing.T) {
+	// PQTC = Position Query Test Cases
+	type PQTC struct {
+		desc                   string
+		positionFen            Fen
+		expectedSideToMove     Color
+		expectedCastlingRights CastlingRights
+		expectedEnPassant      Square
+		expectedHasEnPassant   bool
+		expectedHalfMoveClock  int
+		expectedFullMoveNumber int
+		expectedCheckers       Bitboard
+	}
+
+	tcs := []PQTC{
+		{
+			"starting position",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			White, CastlingRights{{true, true}, {true, true}}, 0, false, 0, 1, 0,
+		},
+		{
+			"en passant square",
+			"rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b Kq e3 0 3",
+			Black, CastlingRights{{true, false}, {false, true}}, E3, true, 0, 3, 0,
+		},
+		{
+			"single check",
+			"rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3",
+			White, CastlingRights{{true, true}, {true, true}}, 0, false, 1, 3, Bitboard(1 << H4),
+		},
+		{
+			"double check",
+			"4k3/8/8/8/8/5n2/8/4r1K1 w - - 12 40",
+			White, CastlingRights{}, 0, false, 12, 40, Bitboard(1<<F3 | 1<<E1),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, err := tc.positionFen.Parse()
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedSideToMove, position.SideToMove())
+			assert.Equal(t, tc.expectedCastlingRights, position.CastlingRights())
+			sq, ok := position.EnPassantSquare()
+			assert.Equal(t, tc.expectedHasEnPassant, ok)
+			assert.Equal(t, tc.expectedEnPassant, sq)
+			assert.Equal(t, tc.expectedHalfMoveClock, position.HalfMoveClock())
+			assert.Equal(t, tc.expectedFullMoveNumber, position.FullMoveNumber())
+			assert.Equal(t, tc.expectedCheckers, position.Checkers())
+			assert.Equal(t, tc.expectedCheckers != 0, position.InCheck())
+
+			for c := White; c < TotalColors; c++ {
+				var occupancy Bitboard
+				for pt := Pawn; pt < TotalPieceTypes; pt++ {
+					occupancy |= position.Pieces(c, pt)
+				}
+				assert.Equal(t, occupancy, position.Occupancy(c))
+				assert.Equal(t, NewPiece(c, King), position.PieceAt(position.KingSquare(c)))
+			}
+			assert.Equal(t, position.Occupancy(White)|position.Occupancy(Black), position.Occupied())
+		})
+	}
+}
@@ -20,61 +20,127 @@ type UpdatePositionTestCase struct {
 	expectedFinalPositionFen Fen
 }
 
-// func TestUpdatePosition_Normal(t *testing.T) {
-// 	tcs := []UpdatePositionTestCase{
-// 		{
-// 			"1",
-// 			Normal + squaresToMove(d2, d4),
-// 			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
-// 			"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq d3 0 1",
-// 		},
-// 		{
-// 			"2",
-// 			Normal + squaresToMove(d4, e5),
-// 			"rnbqkbnr/pppp1ppp/8/4p3/3P4/8/PPP1PPPP/RNBQKBNR w KQkq e6 0 2",
-// 			"rnbqkbnr/pppp1ppp/8/4P3/8/8/PPP1PPPP/RNBQKBNR b KQkq - 0 2",
-// 		},
-// 	}
-
-// 	for _, tc := range tcs {
-// 		t.Run(tc.desc, func(t *testing.T) {
-// 			initialPosition, _ := tc.initialPositionFen.Parse()
-// 			expectedFinalPosition, _ := tc.expectedFinalPositionFen.Parse()
-// 			actualFinalPosition, _ := initialPosition.makeMove(tc.move)
-// 			assert.Equal(t, actualFinalPosition.activeColor, expectedFinalPosition.activeColor)
-// 			assert.Equal(t, actualFinalPosition.piecePlacement, expectedFinalPosition.piecePlacement)
-// 		})
-// 	}
-// }
-
-// func TestUpdatePosition_Promotion(t *testing.T) {
-// 	tcs := []UpdatePositionTestCase{
-// 		{
-// 			"1",
-// 			Promotion + QueenPromotion + squaresToMove(d7, d8),
-// 			"2K1R3/R2P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1",
-// 			"2KQR3/R5k1/8/p7/8/3n2q1/1P6/6r1 b - - 0 1",
-// 		},
-// 		{
-// 			"2",
-// 			Promotion + BishopPromotion + squaresToMove(d7, e8),
-// 			"4r3/RK1P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1",
-// 			"4B3/RK4k1/8/p7/8/3n2q1/1P6/6r1 b - - 0 1",
-// 		},
-// 	}
-
-// 	for _, tc := range tcs {
-// 		t.Run(tc.desc, func(t *testing.T) {
-// 			initialPosition, _ := tc.initialPositionFen.Parse()
-// 			expectedFinalPosition, _ := tc.expectedFinalPositionFen.Parse()
-// 			actualFinalPosition, _ := initialPosition.makeMove(tc.move)
-// 			assert.Equal(t, actualFinalPosition.activeColor, expectedFinalPosition.activeColor)
-// 			assert.Equal(t, actualFinalPosition.piecePlacement, expectedFinalPosition.piecePlacement)
-// 		})
-// 	}
-// }
-
-// // write test cases for castling & enpassant
+var updatePositionTestCases = []UpdatePositionTestCase{
+	{
+		"double pawn push",
+		squaresToMove(D2, D4, DoublePawnPush),
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq d3 0 1",
+	},
+	{
+		"pawn capture",
+		squaresToMove(D4, E5, Capture),
+		"rnbqkbnr/pppp1ppp/8/4p3/3P4/8/PPP1PPPP/RNBQKBNR w KQkq e6 0 2",
+		"rnbqkbnr/pppp1ppp/8/4P3/8/8/PPP1PPPP/RNBQKBNR b KQkq - 0 2",
+	},
+	{
+		"white knight move",
+		squaresToMove(G1, F3, Normal),
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"rnbqkbnr/pppppppp/8/8/8/5N2/PPPPPPPP/RNBQKB1R b KQkq - 1 1",
+	},
+	{
+		"black knight move",
+		squaresToMove(G8, F6, Normal),
+		"rnbqkbnr/pppppppp/8/8/8/5N2/PPPPPPPP/RNBQKB1R b KQkq - 1 1",
+		"rnbqkb1r/pppppppp/5n2/8/8/5N2/PPPPPPPP/RNBQKB1R w KQkq - 2 2",
+	},
+	{
+		"queen promotion",
+		squaresToMove(D7, D8, QueenPromotionNormal),
+		"2K1R3/R2P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1",
+		"2KQR3/R5k1/8/p7/8/3n2q1/1P6/6r1 b - - 0 1",
+	},
+	{
+		"bishop promotion capture",
+		squaresToMove(D7, E8, BishopPromotionCapture),
+		"4r3/RK1P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1",
+		"4B3/RK4k1/8/p7/8/3n2q1/1P6/6r1 b - - 0 1",
+	},
+	{
+		"white king side castling",
+		WhiteKingSideCastling,
+		"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 3 10",
+		"r3k2r/8/8/8/8/8/8/R4RK1 b kq - 4 10",
+	},
+	{
+		"white queen side castling",
+		WhiteQueenSideCastling,
+		"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 3 10",
+		"r3k2r/8/8/8/8/8/8/2KR3R b kq - 4 10",
+	},
+	{
+		"black king side castling",
+		BlackKingSideCastling,
+		"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 3 10",
+		"r4rk1/8/8/8/8/8/8/R3K2R w KQ - 4 11",
+	},
+	{
+		"black queen side castling",
+		BlackQueenSideCastling,
+		"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 3 10",
+		"2kr3r/8/8/8/8/8/8/R3K2R w KQ - 4 11",
+	},
+	{
+		"rook captured on its home square",
+		squaresToMove(A1, A8, Capture),
+		"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+		"R3k2r/8/8/8/8/8/8/4K2R b Kk - 0 1",
+	},
+	{
+		"king move",
+		squaresToMove(E8, D7, Normal),
+		"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1",
+		"r6r/3k4/8/8/8/8/8/R3K2R w KQ - 1 2",
+	},
+	{
+		"white en passant",
+		squaresToMove(E5, F6, EnPassant),
+		"rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3",
+		"rnbqkbnr/ppp1p1pp/5P2/3p4/8/8/PPPP1PPP/RNBQKBNR b KQkq - 0 3",
+	},
+	{
+		"black en passant",
+		squaresToMove(D4, E3, EnPassant),
+		"rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 3",
+		"rnbqkbnr/ppp1pppp/8/8/8/4p3/PPPP1PPP/RNBQKBNR w KQkq - 0 4",
+	},
+}
+
+func TestMakeMove(t *testing.T) {
+	for _, tc := range updatePositionTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actualFinalPosition, _ := tc.initialPositionFen.Parse()
+			expectedFinalPosition, _ := tc.expectedFinalPositionFen.Parse()
+			actualFinalPosition.MakeMove(tc.move)
+			assert.Equal(t, expectedFinalPosition, actualFinalPosition)
+		})
+	}
+}
+
+func TestUnmakeMove(t *testing.T) {
+	for _, tc := range updatePositionTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.initialPositionFen.Parse()
+			expectedPosition, _ := tc.initialPositionFen.Parse()
+			undo := position.MakeMove(tc.move)
+			position.UnmakeMove(undo)
+			assert.Equal(t, expectedPosition, position)
+		})
+	}
+}
+
+func TestPositionCopy(t *testing.T) {
+	for _, tc := range updatePositionTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.initialPositionFen.Parse()
+			copied := position
+			copied.MakeMove(tc.move)
+			assert.Equal(t, tc.initialPositionFen, position.Fen())
+			assert.Equal(t, tc.expectedFinalPositionFen, copied.Fen())
+		})
+	}
+}
 
 func TestCalculateOurKingDangerSquares(t *testing.T) {
 	// KDSTC = King Danger Squares Test Cases
@@ -103,6 +169,7 @@ func TestCalculateOurKingDangerSquares(t *testing.T) {
 				{
 					Rook,
 					Bitboard(0x1000000000),
+					E5,
 				},
 			},
 		},
@@ -120,6 +187,7 @@ func TestCalculateOurKingDangerSquares(t *testing.T) {
 				{
 					Knight,
 					Bitboard(0x200000000000),
+					F6,
 				},
 			},
 		},
@@ -131,10 +199,12 @@ func TestCalculateOurKingDangerSquares(t *testing.T) {
 				{
 					Knight,
 					Bitboard(0x40000000000000),
+					G7,
 				},
 				{
 					Rook,
 					Bitboard(0x1000000000),
+					E5,
 				},
 			},
 		},
@@ -156,3 +226,154 @@ func TestCalculateOurKingDangerSquares(t *testing.T) {
 	}
 
 }
+
+func TestCalculateAbsolutePinnedPieces(t *testing.T) {
+	// APPTC = Absolute Pinned Pieces Test Cases
+	type APPTC struct {
+		desc                 string
+		positionFen          Fen
+		expectedPinnedPieces []PinnedPiece
+	}
+
+	tcs := []APPTC{
+		{
+			"file pin",
+			"4k3/4r3/8/8/8/8/4N3/4K3 w - - 0 1",
+			[]PinnedPiece{
+				{
+					Bitboard(0x1000),
+					Bitboard(0x10101010101000),
+				},
+			},
+		},
+		{
+			"diagonal pin",
+			"4k3/8/8/q7/8/8/3B4/4K3 w - - 0 1",
+			[]PinnedPiece{
+				{
+					Bitboard(0x800),
+					Bitboard(0x102040800),
+				},
+			},
+		},
+		{
+			"two of our pieces between king & slider",
+			"4k3/4r3/8/8/4P3/8/4N3/4K3 w - - 0 1",
+			[]PinnedPiece{},
+		},
+		{
+			"opponent piece between king & slider",
+			"4k3/4r3/8/4p3/8/8/4N3/4K3 w - - 0 1",
+			[]PinnedPiece{},
+		},
+		{
+			"slider which can't pin along the line",
+			"4k3/4b3/8/8/8/8/4N3/4K3 w - - 0 1",
+			[]PinnedPiece{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.positionFen.Parse()
+			actualPinnedPieces := position.calculateAbsolutePinnedPieces()
+			assert.ElementsMatch(t, tc.expectedPinnedPieces, actualPinnedPieces)
+		})
+	}
+}
+
+func TestCalculateCapturePushMask(t *testing.T) {
+	// CPMTC = Capture Push Mask Test Cases
+	type CPMTC struct {
+		desc                string
+		positionFen         Fen
+		expectedCaptureMask Bitboard
+		expectedPushMask    Bitboard
+	}
+
+	tcs := []CPMTC{
+		{
+			"pawn check",
+			"4k3/8/8/8/8/8/3p4/4K3 w - - 0 1",
+			0x800,
+			0,
+		},
+		{
+			"knight check",
+			"4k3/8/8/8/8/3n4/8/4K3 w - - 0 1",
+			0x80000,
+			0,
+		},
+		{
+			"bishop check",
+			"4k3/8/8/8/1b6/8/8/4K3 w - - 0 1",
+			0x2000000,
+			0x40800,
+		},
+		{
+			"rook check along file",
+			"4k3/4r3/8/8/8/8/8/4K3 w - - 0 1",
+			0x10000000000000,
+			0x101010101000,
+		},
+		{
+			"rook check along rank",
+			"4k3/8/8/8/8/8/8/r3K3 w - - 0 1",
+			0x1,
+			0xe,
+		},
+		{
+			"queen check along diagonal",
+			"4k3/8/8/8/8/6q1/8/4K3 w - - 0 1",
+			0x400000,
+			0x2000,
+		},
+		{
+			"queen check along file",
+			"4k3/8/8/4q3/8/8/8/4K3 w - - 0 1",
+			0x1000000000,
+			0x10101000,
+		},
+		{
+			"contact check by queen",
+			"4k3/8/8/8/8/8/4q3/4K3 w - - 0 1",
+			0x1000,
+			0,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.positionFen.Parse()
+			assert.Len(t, position.kingCheckers, 1)
+			assert.Equal(t, tc.expectedCaptureMask, position.captureMask)
+			assert.Equal(t, tc.expectedPushMask, position.pushMask)
+		})
+	}
+}
+
+func BenchmarkGenerateAuxiliaryInfo(b *testing.B) {
+	position, _ := Fen("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1").Parse()
+	for i := 0; i < b.N; i++ {
+		position.generateAuxiliaryInfo()
+	}
+}
+
+func TestPieceAt(t *testing.T) {
+	position, _ := Fen("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1").Parse()
+
+	assert.Equal(t, NewPiece(White, Rook), position.PieceAt(A1))
+	assert.Equal(t, NewPiece(Black, Queen), position.PieceAt(E7))
+	assert.Equal(t, NewPiece(White, Knight), position.PieceAt(E5))
+	assert.Equal(t, NoPiece, position.PieceAt(E3))
+	assert.Equal(t, Black, position.PieceAt(H3).Color())
+	assert.Equal(t, Pawn, position.PieceAt(H3).Type())
+	assert.Equal(t, "B", position.PieceAt(D2).String())
+
+	move, _ := position.ParseUCIMove("e5f7")
+	undo := position.MakeMove(move)
+	assert.Equal(t, NoPiece, position.PieceAt(E5))
+	assert.Equal(t, NewPiece(White, Knight), position.PieceAt(F7))
+	position.UnmakeMove(undo)
+	assert.Equal(t, NewPiece(Black, Pawn), position.PieceAt(F7))
+}
@@ -0,0 +1,183 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+)
+
+// https://en.wikipedia.org/wiki/Algebraic_notation_(chess)
+// Move in standard algebraic notation, e.g. Nbd7, exd6, O-O, e8=Q+
+func (position Position) SAN(move Move) string {
+	us := position.activeColor
+	from, to := move.fromTo()
+	movedPiece := position.pieceTypeOn(us, from)
+
+	san := ""
+	switch {
+	case move.moveType() == WhiteKingSideCastling || move.moveType() == BlackKingSideCastling:
+		san = "O-O"
+	case move.isCastling():
+		san = "O-O-O"
+	case movedPiece == Pawn:
+		if move.IsCapture() {
+			san += from.String()[:1] + "x"
+		}
+		san += to.String()
+		if move.isPromotion() {
+			san += "=" + move.PromotionPieceType().fenRep(White)
+		}
+	default:
+		san = movedPiece.fenRep(White) + position.sanDisambiguation(move, movedPiece)
+		if move.IsCapture() {
+			san += "x"
+		}
+		san += to.String()
+	}
+
+	// check & checkmate suffix
+	p := position
+	p.MakeMove(move)
+	if len(p.kingCheckers) > 0 {
+		if len(GenerateAllMoves(p, 1)) == 0 {
+			san += "#"
+		} else {
+			san += "+"
+		}
+	}
+
+	return san
+}
+
+// Minimal file, rank or square of the moved piece needed when other pieces of the same type can reach the square
+func (position Position) sanDisambiguation(move Move, movedPiece PieceType) string {
+	from, to := move.From(), move.To()
+
+	ambiguous, sameFile, sameRank := false, false, false
+	for _, legalMove := range GenerateAllMoves(position, 1) {
+		otherFrom := legalMove.From()
+		if legalMove.isCastling() || legalMove.To() != to || otherFrom == from || position.pieceTypeOn(position.activeColor, otherFrom) != movedPiece {
+			continue
+		}
+		ambiguous = true
+		sameFile = sameFile || otherFrom.File() == from.File()
+		sameRank = sameRank || otherFrom.Rank() == from.Rank()
+	}
+
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return from.String()[:1]
+	case !sameRank:
+		return from.String()[1:]
+	default:
+		return from.String()
+	}
+}
+
+// Parses a move in standard algebraic notation, ignoring check, mate & annotation suffixes like !?
+// and accepting castling written with zeros
+func (position Position) ParseSAN(san string) (Move, error) {
+	s := strings.TrimRight(strings.TrimSpace(san), "+#!? ")
+	s = strings.TrimSuffix(s, "e.p.")
+	s = strings.TrimSuffix(s, "ep")
+	s = strings.TrimRight(s, "+#!? ")
+
+	// castling
+	var castlingMoves []Move
+	switch strings.ReplaceAll(s, "0", "O") {
+	case "O-O":
+		castlingMoves = []Move{WhiteKingSideCastling, BlackKingSideCastling}
+	case "O-O-O":
+		castlingMoves = []Move{WhiteQueenSideCastling, BlackQueenSideCastling}
+	}
+	if castlingMoves != nil {
+		for _, legalMove := range GenerateAllMoves(position, 1) {
+			if legalMove == castlingMoves[0] || legalMove == castlingMoves[1] {
+				return legalMove, nil
+			}
+		}
+		return 0, fmt.Errorf("san move %q: %w", san, ErrIllegalMove)
+	}
+
+	// promotion piece, e.g. e8=Q or e8Q
+	var promotion PieceType
+	if len(s) > 2 {
+		if pt := pieceTypeFromSAN(s[len(s)-1]); pt != 0 {
+			promotion = pt
+			s = strings.TrimSuffix(s[:len(s)-1], "=")
+		}
+	}
+
+	// moved piece, pawn moves start with a file
+	movedPiece := Pawn
+	if len(s) > 0 {
+		if pt := pieceTypeFromSAN(s[0]); pt != 0 {
+			movedPiece = pt
+			s = s[1:]
+		}
+	}
+
+	// destination square is always last
+	if len(s) < 2 {
+		return 0, fmt.Errorf("san move %q: destination square missing", san)
+	}
+	to, err := ParseSquare(s[len(s)-2:])
+	if err != nil {
+		return 0, fmt.Errorf("san move %q: %w", san, err)
+	}
+
+	// disambiguation file and/or rank before the optional capture
+	disambiguation := strings.TrimSuffix(s[:len(s)-2], "x")
+	fromFile, fromRank := -1, -1
+	for i := 0; i < len(disambiguation); i++ {
+		switch c := disambiguation[i]; {
+		case c >= 'a' && c <= 'h':
+			fromFile = int(c - 'a')
+		case c >= '1' && c <= '8':
+			fromRank = int(c - '1')
+		default:
+			return 0, fmt.Errorf("san move %q: invalid char %s", san, string(c))
+		}
+	}
+
+	var matches []Move
+	for _, legalMove := range GenerateAllMoves(position, 1) {
+		from := legalMove.From()
+		if legalMove.isCastling() || legalMove.To() != to ||
+			position.pieceTypeOn(position.activeColor, from) != movedPiece ||
+			legalMove.PromotionPieceType() != promotion ||
+			(fromFile >= 0 && from.File() != fromFile) ||
+			(fromRank >= 0 && from.Rank() != fromRank) {
+			continue
+		}
+		matches = append(matches, legalMove)
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("san move %q: %w", san, ErrIllegalMove)
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, fmt.Errorf("san move %q: ambiguous between %d moves", san, len(matches))
+	}
+}
+
+// Piece type of uppercase piece letter, 0 for any other character
+func pieceTypeFromSAN(c byte) PieceType {
+	switch c {
+	case 'N':
+		return Knight
+	case 'B':
+		return Bishop
+	case 'R':
+		return Rook
+	case 'Q':
+		return Queen
+	case 'K':
+		return King
+	default:
+		return 0
+	}
+}
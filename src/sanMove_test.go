@@ -0,0 +1,100 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type SANTestCase struct {
+	desc        string
+	positionFen Fen
+	move        Move
+	san         string
+}
+
+var sanTestCases = []SANTestCase{
+	{"pawn push", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", squaresToMove(E2, E4, DoublePawnPush), "e4"},
+	{"knight move", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", squaresToMove(G1, F3, Normal), "Nf3"},
+	{"pawn capture", "rnbqkbnr/pppp1ppp/8/4p3/3P4/8/PPP1PPPP/RNBQKBNR w KQkq e6 0 2", squaresToMove(D4, E5, Capture), "dxe5"},
+	{"en passant", "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", squaresToMove(E5, F6, EnPassant), "exf6"},
+	{"king side castling", "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", WhiteKingSideCastling, "O-O"},
+	{"queen side castling", "r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", BlackQueenSideCastling, "O-O-O"},
+	{"promotion", "2K1R3/R2P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1", squaresToMove(D7, D8, QueenPromotionNormal), "d8=Q+"},
+	{"promotion capture", "4r3/RK1P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1", squaresToMove(D7, E8, KnightPromotionCapture), "dxe8=N+"},
+	{"file disambiguation", "r3k2r/8/8/8/8/8/8/R4RK1 w kq - 0 1", squaresToMove(A1, D1, Normal), "Rad1"},
+	{"rank disambiguation", "4k3/8/8/R7/8/8/8/R3K3 w - - 0 1", squaresToMove(A5, A3, Normal), "R5a3"},
+	{"square disambiguation", "4k3/8/8/8/8/Q1Q5/8/Q3K3 w - - 0 1", squaresToMove(A3, B2, Normal), "Qa3b2"},
+	{"no disambiguation for pinned piece", "4r2k/8/8/8/8/8/4N3/1N2K3 w - - 0 1", squaresToMove(B1, D2, Normal), "Nd2"},
+	{"capture with disambiguation", "4k3/8/8/8/3p4/1N6/4N3/4K3 w - - 0 1", squaresToMove(E2, D4, Capture), "Nexd4"},
+	{"checkmate", "rnbqkbnr/pppp1ppp/8/4p3/6P1/5P2/PPPPP2P/RNBQKBNR b KQkq - 0 2", squaresToMove(D8, H4, Normal), "Qh4#"},
+}
+
+func TestSAN(t *testing.T) {
+	for _, tc := range sanTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.positionFen.Parse()
+			assert.Equal(t, tc.san, position.SAN(tc.move))
+
+			// position is left unchanged
+			expectedPosition, _ := tc.positionFen.Parse()
+			assert.Equal(t, expectedPosition, position)
+		})
+	}
+}
+
+func TestParseSAN(t *testing.T) {
+	for _, tc := range sanTestCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.positionFen.Parse()
+			move, err := position.ParseSAN(tc.san)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.move, move)
+		})
+	}
+}
+
+func TestParseSAN_Lenient(t *testing.T) {
+	position, _ := Fen("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1").Parse()
+	for _, san := range []string{"0-0", "O-O!?", "O-O+", "0-0!!"} {
+		move, err := position.ParseSAN(san)
+		assert.NoError(t, err, san)
+		assert.Equal(t, WhiteKingSideCastling, move, san)
+	}
+
+	enPassant, _ := Fen("rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3").Parse()
+	for _, san := range []string{"exf6", "exf6 e.p.", "exf6e.p.", "ef6", "exf6?!"} {
+		move, err := enPassant.ParseSAN(san)
+		assert.NoError(t, err, san)
+		assert.Equal(t, squaresToMove(E5, F6, EnPassant), move, san)
+	}
+
+	promotion, _ := Fen("2K1R3/R2P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1").Parse()
+	move, err := promotion.ParseSAN("d8Q")
+	assert.NoError(t, err)
+	assert.Equal(t, squaresToMove(D7, D8, QueenPromotionNormal), move)
+}
+
+func TestParseSAN_Errors(t *testing.T) {
+	position, _ := Fen("r3k2r/8/8/8/8/8/8/R4RK1 w kq - 0 1").Parse()
+	for _, san := range []string{"", "R", "Rd9", "Rd1", "Rzd1", "Nf3", "e4", "O-O", "O-O-O-O"} {
+		_, err := position.ParseSAN(san)
+		assert.Error(t, err, san)
+	}
+}
+
+func TestSAN_RoundTrip(t *testing.T) {
+	for _, fen := range fenTestSuite {
+		position, _ := fen.Parse()
+		sans := make(map[string]bool)
+		for _, move := range GenerateAllMoves(position, 1) {
+			san := position.SAN(move)
+			assert.False(t, sans[san], "%s %s", fen, san)
+			sans[san] = true
+
+			parsedMove, err := position.ParseSAN(san)
+			assert.NoError(t, err, "%s %s", fen, san)
+			assert.Equal(t, move, parsedMove, "%s %s", fen, san)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !hyperbola
+
+package src
+
+// Rook & bishop attacks used by the move generator, by magic bitboards
+// Build with the hyperbola tag to use Hyperbola Quintessence instead
+
+func rookAttacks(sq Square, occ Bitboard) Bitboard {
+	return magicRookAttacks(sq, occ)
+}
+
+func bishopAttacks(sq Square, occ Bitboard) Bitboard {
+	return magicBishopAttacks(sq, occ)
+}
@@ -0,0 +1,13 @@
+//go:build hyperbola
+
+package src
+
+// Rook & bishop attacks used by the move generator, by Hyperbola Quintessence
+
+func rookAttacks(sq Square, occ Bitboard) Bitboard {
+	return hqRookAttacks(sq, occ)
+}
+
+func bishopAttacks(sq Square, occ Bitboard) Bitboard {
+	return hqBishopAttacks(sq, occ)
+}
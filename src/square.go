@@ -0,0 +1,55 @@
+package src
+
+import "fmt"
+
+// Square in algebraic notation, e.g. e4, "-" if it is not on the board
+func (sq Square) String() string {
+	if sq > H8 {
+		return "-"
+	}
+	return string(rune('a'+sq.File())) + string(rune('1'+sq.Rank()))
+}
+
+// Parses a square in algebraic notation, e.g. e4
+func ParseSquare(s string) (Square, error) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return 0, fmt.Errorf("square %q in wrong format", s)
+	}
+	return Square(s[1]-'1')*8 + Square(s[0]-'a'), nil
+}
+
+// File of the square, 0 for the a file to 7 for the h file
+func (sq Square) File() int {
+	return int(sq % 8)
+}
+
+// Rank of the square, 0 for the first rank to 7 for the eighth rank
+func (sq Square) Rank() int {
+	return int(sq / 8)
+}
+
+// Square on the same file with the rank flipped, e.g. e2 & e7
+func (sq Square) Mirror() Square {
+	return sq ^ 56
+}
+
+// Moves a king needs to go from one square to the other
+func ChebyshevDistance(sq1, sq2 Square) int {
+	fileDistance, rankDistance := abs(sq1.File()-sq2.File()), abs(sq1.Rank()-sq2.Rank())
+	if fileDistance > rankDistance {
+		return fileDistance
+	}
+	return rankDistance
+}
+
+// Sum of the file and rank distances between the squares
+func ManhattanDistance(sq1, sq2 Square) int {
+	return abs(sq1.File()-sq2.File()) + abs(sq1.Rank()-sq2.Rank())
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
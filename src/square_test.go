@@ -0,0 +1,71 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSquare(t *testing.T) {
+	// SqTC = Square Test Cases
+	type SqTC struct {
+		name           string
+		expectedSquare Square
+		expectedFile   int
+		expectedRank   int
+		expectedMirror Square
+	}
+
+	tcs := []SqTC{
+		{"a1", A1, 0, 0, A8},
+		{"h1", H1, 7, 0, H8},
+		{"e4", E4, 4, 3, E5},
+		{"c7", C7, 2, 6, C2},
+		{"h8", H8, 7, 7, H1},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			sq, err := ParseSquare(tc.name)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSquare, sq)
+			assert.Equal(t, tc.name, sq.String())
+			assert.Equal(t, tc.expectedFile, sq.File())
+			assert.Equal(t, tc.expectedRank, sq.Rank())
+			assert.Equal(t, tc.expectedMirror, sq.Mirror())
+		})
+	}
+
+	assert.Equal(t, "-", Square(64).String())
+	for _, s := range []string{"", "e", "e9", "i4", "E4", "e44"} {
+		_, err := ParseSquare(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestSquareDistance(t *testing.T) {
+	// SDTC = Square Distance Test Cases
+	type SDTC struct {
+		sq1, sq2          Square
+		expectedChebyshev int
+		expectedManhattan int
+	}
+
+	tcs := []SDTC{
+		{E4, E4, 0, 0},
+		{A1, H8, 7, 14},
+		{A8, H1, 7, 14},
+		{E1, E8, 7, 7},
+		{B2, D3, 2, 3},
+		{G7, F5, 2, 3},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.sq1.String()+"-"+tc.sq2.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expectedChebyshev, ChebyshevDistance(tc.sq1, tc.sq2))
+			assert.Equal(t, tc.expectedChebyshev, ChebyshevDistance(tc.sq2, tc.sq1))
+			assert.Equal(t, tc.expectedManhattan, ManhattanDistance(tc.sq1, tc.sq2))
+			assert.Equal(t, tc.expectedManhattan, ManhattanDistance(tc.sq2, tc.sq1))
+		})
+	}
+}
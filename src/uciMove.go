@@ -0,0 +1,121 @@
+package src
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrIllegalMove = errors.New("illegal move")
+
+// Parses a move in uci long algebraic notation, e.g. e2e4, e7e8q, e1g1
+// & resolves its move type from the position
+func (position Position) ParseUCIMove(uci string) (Move, error) {
+	if len(uci) != 4 && len(uci) != 5 {
+		return 0, fmt.Errorf("uci move %q: wrong length", uci)
+	}
+
+	from, err := ParseSquare(uci[0:2])
+	if err != nil {
+		return 0, fmt.Errorf("uci move %q: %w", uci, err)
+	}
+	to, err := ParseSquare(uci[2:4])
+	if err != nil {
+		return 0, fmt.Errorf("uci move %q: %w", uci, err)
+	}
+
+	var promotion PieceType
+	if len(uci) == 5 {
+		switch uci[4] {
+		case 'n':
+			promotion = Knight
+		case 'b':
+			promotion = Bishop
+		case 'r':
+			promotion = Rook
+		case 'q':
+			promotion = Queen
+		default:
+			return 0, fmt.Errorf("uci move %q: invalid promotion piece %s", uci, string(uci[4]))
+		}
+	}
+
+	move, err := position.resolveMove(from, to, promotion)
+	if err != nil {
+		return 0, fmt.Errorf("uci move %q: %w", uci, err)
+	}
+	return move, nil
+}
+
+// Move type of the move between squares, checking that the move is legal
+func (position Position) resolveMove(from, to Square, promotion PieceType) (Move, error) {
+	us, opp := position.activeColor, position.activeColor.Opponent()
+	movedPiece := position.pieceTypeOn(us, from)
+	capturedPiece := position.pieceTypeOn(opp, to)
+	if movedPiece == 0 {
+		return 0, fmt.Errorf("no %v piece on %s: %w", us, from, ErrIllegalMove)
+	}
+
+	moveType := Normal
+	if capturedPiece != 0 {
+		moveType = Capture
+	}
+
+	switch movedPiece {
+	case King:
+		for _, castling := range []Move{WhiteKingSideCastling, WhiteQueenSideCastling, BlackKingSideCastling, BlackQueenSideCastling} {
+			kingFrom, kingTo, _, _ := castlingSquares(castling)
+			if from == kingFrom && to == kingTo {
+				moveType = castling
+			}
+		}
+	case Pawn:
+		lastRank := (us == White && to >= A8) || (us == Black && to <= H1)
+		switch {
+		case lastRank && promotion == 0:
+			return 0, fmt.Errorf("promotion piece missing: %w", ErrIllegalMove)
+		case lastRank:
+			moveType = promotionMove(promotion, capturedPiece != 0)
+		case to == position.enPassantTarget && from.File() != to.File():
+			moveType = EnPassant
+		case from+16 == to || to+16 == from:
+			moveType = DoublePawnPush
+		}
+	}
+
+	if promotion != 0 && !moveType.isPromotion() {
+		return 0, fmt.Errorf("promotion of a non pawn move: %w", ErrIllegalMove)
+	}
+
+	move := squaresToMove(from, to, moveType)
+	if moveType.isCastling() {
+		move = moveType
+	}
+
+	for _, legalMove := range GenerateAllMoves(position, 1) {
+		if legalMove == move {
+			return move, nil
+		}
+	}
+	return 0, ErrIllegalMove
+}
+
+// Promotion move type for the piece type
+func promotionMove(pt PieceType, capture bool) Move {
+	var moveType Move
+	switch pt {
+	case Knight:
+		moveType = KnightPromotionNormal
+	case Bishop:
+		moveType = BishopPromotionNormal
+	case Rook:
+		moveType = RookPromotionNormal
+	default:
+		moveType = QueenPromotionNormal
+	}
+
+	if capture {
+		// promotion capture codes follow their normal promotion codes
+		moveType += Capture
+	}
+	return moveType
+}
@@ -0,0 +1,77 @@
+package src
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveUCI(t *testing.T) {
+	tcs := map[Move]string{
+		squaresToMove(E2, E4, DoublePawnPush):         "e2e4",
+		squaresToMove(G1, F3, Normal):                 "g1f3",
+		squaresToMove(E7, E8, QueenPromotionNormal):   "e7e8q",
+		squaresToMove(B2, A1, KnightPromotionCapture): "b2a1n",
+		WhiteKingSideCastling:                         "e1g1",
+		WhiteQueenSideCastling:                        "e1c1",
+		BlackKingSideCastling:                         "e8g8",
+		BlackQueenSideCastling:                        "e8c8",
+	}
+
+	for move, expected := range tcs {
+		assert.Equal(t, expected, move.UCI())
+	}
+}
+
+func TestParseUCIMove(t *testing.T) {
+	type PUMTC struct {
+		desc         string
+		positionFen  Fen
+		uci          string
+		expectedMove Move
+	}
+
+	tcs := []PUMTC{
+		{"double pawn push", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2e4", squaresToMove(E2, E4, DoublePawnPush)},
+		{"knight move", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "g1f3", squaresToMove(G1, F3, Normal)},
+		{"capture", "rnbqkbnr/pppp1ppp/8/4p3/3P4/8/PPP1PPPP/RNBQKBNR w KQkq e6 0 2", "d4e5", squaresToMove(D4, E5, Capture)},
+		{"en passant", "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", "e5f6", squaresToMove(E5, F6, EnPassant)},
+		{"white king side castling", "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1g1", WhiteKingSideCastling},
+		{"black queen side castling", "r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "e8c8", BlackQueenSideCastling},
+		{"queen promotion", "2K1R3/R2P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1", "d7d8q", squaresToMove(D7, D8, QueenPromotionNormal)},
+		{"bishop promotion capture", "4r3/RK1P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1", "d7e8b", squaresToMove(D7, E8, BishopPromotionCapture)},
+		{"king move", "r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "e8d7", squaresToMove(E8, D7, Normal)},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := tc.positionFen.Parse()
+			move, err := position.ParseUCIMove(tc.uci)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedMove, move)
+		})
+	}
+}
+
+func TestParseUCIMove_Errors(t *testing.T) {
+	position, _ := Fen("2K1R3/R2P2k1/8/p7/8/3n2q1/1P6/6r1 w - - 0 1").Parse()
+
+	for _, uci := range []string{"", "d7", "d7d8qq", "i7d8", "d7d9", "d7d8k", "d7d8", "a7a8q", "b2b5", "a5a4", "e8e9"} {
+		_, err := position.ParseUCIMove(uci)
+		assert.Error(t, err, uci)
+	}
+
+	_, err := position.ParseUCIMove("c8c7")
+	assert.ErrorIs(t, err, ErrIllegalMove)
+}
+
+func TestParseUCIMove_RoundTrip(t *testing.T) {
+	for _, fen := range fenTestSuite {
+		position, _ := fen.Parse()
+		for _, move := range GenerateAllMoves(position, 1) {
+			parsedMove, err := position.ParseUCIMove(move.UCI())
+			assert.NoError(t, err, "%s %s", fen, move.UCI())
+			assert.Equal(t, move, parsedMove, "%s %s", fen, move.UCI())
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package src
+
+// offsets of the polyglotRandoms
+const (
+	zobristCastlingOffset  = 768
+	zobristEnPassantOffset = 772
+	zobristTurnOffset      = 780
+)
+
+// Zobrist key of the position, same as the key of polyglot opening books
+func (position Position) ZobristKey() uint64 {
+	return position.zobristKey
+}
+
+// Calculates the key from scratch
+func (position Position) calculateZobristKey() (key uint64) {
+	for _, c := range []Color{White, Black} {
+		for pt := Pawn; pt < TotalPieceTypes; pt++ {
+			key ^= zobristPieces(c, pt, position.piecePlacement[c][pt])
+		}
+	}
+
+	key ^= position.zobristCastling() ^ position.zobristEnPassant()
+	if position.activeColor == White {
+		key ^= polyglotRandoms[zobristTurnOffset]
+	}
+	return key
+}
+
+// Key of the pawns of both colors, calculated from scratch
+func (position Position) calculatePawnKey() uint64 {
+	return zobristPieces(White, Pawn, position.piecePlacement[White][Pawn]) ^ zobristPieces(Black, Pawn, position.piecePlacement[Black][Pawn])
+}
+
+// Key of the pieces of color & piece type on the squares set in the bitboard
+func zobristPieces(c Color, pt PieceType, bb Bitboard) (key uint64) {
+	kind := 2 * (int(pt) - 1)
+	if c == White {
+		kind++
+	}
+
+	for ; bb > 0; bb &= bb - 1 {
+		key ^= polyglotRandoms[64*kind+int(bb.leftmostSignificantSquare())]
+	}
+	return key
+}
+
+func (position Position) zobristCastling() (key uint64) {
+	castlingTypes := []CastlingType{position.castlingRights[White], position.castlingRights[Black]}
+	for i, castlingType := range castlingTypes {
+		if castlingType.kingSide {
+			key ^= polyglotRandoms[zobristCastlingOffset+2*i]
+		}
+		if castlingType.queenSide {
+			key ^= polyglotRandoms[zobristCastlingOffset+2*i+1]
+		}
+	}
+	return key
+}
+
+// Like polyglot, en passant file is a part of the key only when
+// a pawn of the active color stands next to the pawn which made the double push
+func (position Position) zobristEnPassant() uint64 {
+	ep := position.enPassantTarget
+	if ep >= 64 {
+		return 0
+	}
+
+	us := position.activeColor
+	if PawnAttacks[us.Opponent()][ep]&position.piecePlacement[us][Pawn] == 0 {
+		return 0
+	}
+	return polyglotRandoms[zobristEnPassantOffset+ep.File()]
+}
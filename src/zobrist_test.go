@@ -0,0 +1,77 @@
+package src
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZobristKey_Polyglot(t *testing.T) {
+	// keys from the polyglot book format specification
+	// ZKTC = Zobrist Key Test Cases
+	type ZKTC struct {
+		desc        string
+		moves       []string
+		fen         Fen
+		expectedKey uint64
+	}
+
+	tcs := []ZKTC{
+		{"starting position", []string{}, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", 0x463b96181691fc9c},
+		{"e2e4", []string{"e2e4"}, "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", 0x823c9b50fd114196},
+		{"e2e4 d7d5", []string{"e2e4", "d7d5"}, "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 2", 0x0756b94461c50fb0},
+		{"e2e4 d7d5 e4e5", []string{"e2e4", "d7d5", "e4e5"}, "rnbqkbnr/ppp1pppp/8/3pP3/8/8/PPPP1PPP/RNBQKBNR b KQkq - 0 2", 0x662fafb965db29d4},
+		{"e2e4 d7d5 e4e5 f7f5", []string{"e2e4", "d7d5", "e4e5", "f7f5"}, "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", 0x22a48b5a8e47ff78},
+		{"e2e4 d7d5 e4e5 f7f5 e1e2", []string{"e2e4", "d7d5", "e4e5", "f7f5", "e1e2"}, "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPPKPPP/RNBQ1BNR b kq - 1 3", 0x652a607ca3f242c1},
+		{"e2e4 d7d5 e4e5 f7f5 e1e2 e8f7", []string{"e2e4", "d7d5", "e4e5", "f7f5", "e1e2", "e8f7"}, "rnbq1bnr/ppp1pkpp/8/3pPp2/8/8/PPPPKPPP/RNBQ1BNR w - - 2 4", 0x00fdd303c946bdd9},
+		{"a2a4 b7b5 h2h4 b5b4 c2c4", []string{"a2a4", "b7b5", "h2h4", "b5b4", "c2c4"}, "rnbqkbnr/p1pppppp/8/8/PpP4P/8/1P1PPPP1/RNBQKBNR b KQkq c3 0 3", 0x3c8123ea7b067637},
+		{"a2a4 b7b5 h2h4 b5b4 c2c4 b4c3 a1a3", []string{"a2a4", "b7b5", "h2h4", "b5b4", "c2c4", "b4c3", "a1a3"}, "rnbqkbnr/p1pppppp/8/8/P6P/R1p5/1P1PPPP1/1NBQKBNR b Kkq - 1 4", 0x5c3f9b829b279560},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			position, _ := Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1").Parse()
+			for _, uci := range tc.moves {
+				move, err := position.ParseUCIMove(uci)
+				assert.NoError(t, err)
+				position.MakeMove(move)
+			}
+			assert.Equal(t, tc.expectedKey, position.ZobristKey())
+
+			position, err := tc.fen.Parse()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedKey, position.ZobristKey())
+		})
+	}
+}
+
+func TestZobristKey_Incremental(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for _, fen := range fenTestSuite {
+		t.Run(string(fen), func(t *testing.T) {
+			position, _ := fen.Parse()
+			initialKey, initialPawnKey := position.ZobristKey(), position.pawnKey
+
+			var undos []Undo
+			for i := 0; i < 100; i++ {
+				moves := GenerateAllMoves(position, 0)
+				if len(moves) == 0 {
+					break
+				}
+				undos = append(undos, position.MakeMove(moves[random.Intn(len(moves))]))
+				if !assert.Equal(t, position.calculateZobristKey(), position.ZobristKey(), position.Fen()) ||
+					!assert.Equal(t, position.calculatePawnKey(), position.pawnKey, position.Fen()) {
+					return
+				}
+			}
+
+			for i := len(undos) - 1; i >= 0; i-- {
+				position.UnmakeMove(undos[i])
+			}
+			assert.Equal(t, initialKey, position.ZobristKey())
+			assert.Equal(t, initialPawnKey, position.pawnKey)
+		})
+	}
+}
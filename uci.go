@@ -0,0 +1,366 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bhavya5jain/go-django-unchained/search"
+	"github.com/bhavya5jain/go-django-unchained/src"
+)
+
+const startingPositionFen = src.Fen("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+
+// https://www.wbec-ridderkerk.nl/html/UCIProtocol.html
+type uciEngine struct {
+	out   io.Writer
+	outMu sync.Mutex
+
+	position src.Position
+
+	// running search
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	options []uciOption
+	// value of Move Overhead option
+	moveOverhead time.Duration
+	// sized by Hash option
+	tt        *search.TranspositionTable
+	evaluator *src.Evaluator
+}
+
+type uciOption struct {
+	name string
+	// option type & constraints as printed after "option name <name>"
+	definition string
+	set        func(value string) error
+}
+
+// Limits of the go command
+type searchLimits struct {
+	depth     int
+	moveTime  time.Duration
+	wTime     time.Duration
+	bTime     time.Duration
+	wInc      time.Duration
+	bInc      time.Duration
+	movesToGo int
+	infinite  bool
+}
+
+func newUCIEngine(out io.Writer) *uciEngine {
+	engine := &uciEngine{
+		out:          out,
+		moveOverhead: 10 * time.Millisecond,
+		tt:           search.NewTranspositionTable(16),
+		evaluator:    src.NewEvaluator(),
+	}
+	engine.position, _ = startingPositionFen.Parse()
+
+	engine.options = []uciOption{
+		{
+			name:       "Move Overhead",
+			definition: "type spin default 10 min 0 max 5000",
+			set: func(value string) error {
+				ms, err := strconv.Atoi(value)
+				if err != nil || ms < 0 || ms > 5000 {
+					return fmt.Errorf("Move Overhead %q not in [0, 5000]", value)
+				}
+				engine.moveOverhead = time.Duration(ms) * time.Millisecond
+				return nil
+			},
+		},
+		{
+			name:       "Hash",
+			definition: "type spin default 16 min 1 max 4096",
+			set: func(value string) error {
+				mb, err := strconv.Atoi(value)
+				if err != nil || mb < 1 || mb > 4096 {
+					return fmt.Errorf("Hash %q not in [1, 4096]", value)
+				}
+				engine.tt.Resize(mb)
+				return nil
+			},
+		},
+	}
+
+	return engine
+}
+
+// Reads uci commands from in till quit or end of input
+func runUCI(in io.Reader, out io.Writer) error {
+	engine := newUCIEngine(out)
+	defer engine.stopSearch()
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "uci":
+			engine.println("id name go-django-unchained")
+			engine.println("id author go-django-unchained authors")
+			for _, option := range engine.options {
+				engine.println("option name " + option.name + " " + option.definition)
+			}
+			engine.println("uciok")
+		case "isready":
+			engine.println("readyok")
+		case "ucinewgame":
+			engine.stopSearch()
+			engine.tt.Clear()
+			engine.position, _ = startingPositionFen.Parse()
+		case "position":
+			engine.stopSearch()
+			if err := engine.setPosition(fields[1:]); err != nil {
+				engine.println("info string " + err.Error())
+			}
+		case "go":
+			engine.stopSearch()
+			limits, err := parseSearchLimits(fields[1:])
+			if err != nil {
+				engine.println("info string " + err.Error())
+				continue
+			}
+			engine.startSearch(limits)
+		case "stop":
+			engine.stopSearch()
+		case "setoption":
+			// options can't change under a running search
+			engine.stopSearch()
+			if err := engine.setOption(fields[1:]); err != nil {
+				engine.println("info string " + err.Error())
+			}
+		case "quit":
+			return nil
+		default:
+			engine.println("info string unknown command " + fields[0])
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (engine *uciEngine) println(s string) {
+	engine.outMu.Lock()
+	defer engine.outMu.Unlock()
+	fmt.Fprintln(engine.out, s)
+}
+
+// position [startpos | fen <fen>] [moves <move1> ... <movei>]
+func (engine *uciEngine) setPosition(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("position: startpos or fen expected")
+	}
+
+	var fen src.Fen
+	moves := []string{}
+	switch args[0] {
+	case "startpos":
+		fen = startingPositionFen
+		args = args[1:]
+	case "fen":
+		i := 1
+		for i < len(args) && args[i] != "moves" {
+			i++
+		}
+		fen = src.Fen(strings.Join(args[1:i], " "))
+		args = args[i:]
+	default:
+		return fmt.Errorf("position: startpos or fen expected, got %s", args[0])
+	}
+	if len(args) > 0 && args[0] == "moves" {
+		moves = args[1:]
+	}
+
+	position, err := fen.Parse()
+	if err != nil {
+		return err
+	}
+	for _, m := range moves {
+		move, err := position.ParseUCIMove(m)
+		if err != nil {
+			return fmt.Errorf("position: %w", err)
+		}
+		position.MakeMove(move)
+	}
+
+	engine.position = position
+	return nil
+}
+
+// setoption name <id> [value <x>]
+func (engine *uciEngine) setOption(args []string) error {
+	if len(args) < 2 || args[0] != "name" {
+		return fmt.Errorf("setoption: name expected")
+	}
+
+	i := 1
+	for i < len(args) && args[i] != "value" {
+		i++
+	}
+	name := strings.Join(args[1:i], " ")
+	value := ""
+	if i < len(args) {
+		value = strings.Join(args[i+1:], " ")
+	}
+
+	for _, option := range engine.options {
+		if strings.EqualFold(option.name, name) {
+			return option.set(value)
+		}
+	}
+	return fmt.Errorf("setoption: unknown option %s", name)
+}
+
+func parseSearchLimits(args []string) (searchLimits, error) {
+	limits := searchLimits{}
+
+	for i := 0; i < len(args); i++ {
+		if args[i] == "infinite" {
+			limits.infinite = true
+			continue
+		}
+		if args[i] == "ponder" {
+			continue
+		}
+
+		if i+1 >= len(args) {
+			return limits, fmt.Errorf("go: value expected after %s", args[i])
+		}
+		value, err := strconv.Atoi(args[i+1])
+		if err != nil {
+			return limits, fmt.Errorf("go: %s %s: %w", args[i], args[i+1], err)
+		}
+		ms := time.Duration(value) * time.Millisecond
+
+		switch args[i] {
+		case "depth":
+			limits.depth = value
+		case "movetime":
+			limits.moveTime = ms
+		case "wtime":
+			limits.wTime = ms
+		case "btime":
+			limits.bTime = ms
+		case "winc":
+			limits.wInc = ms
+		case "binc":
+			limits.bInc = ms
+		case "movestogo":
+			limits.movesToGo = value
+		case "nodes", "mate":
+			// not supported, search is limited by depth & time only
+		default:
+			return limits, fmt.Errorf("go: unknown parameter %s", args[i])
+		}
+		i++
+	}
+
+	return limits, nil
+}
+
+// Time available for the move, false if search is not limited by time
+func (limits searchLimits) timeBudget(sideToMove src.Color, overhead time.Duration) (time.Duration, bool) {
+	if limits.infinite {
+		return 0, false
+	}
+	if limits.moveTime > 0 {
+		return maxDuration(limits.moveTime-overhead, time.Millisecond), true
+	}
+
+	remaining, inc := limits.wTime, limits.wInc
+	if sideToMove == src.Black {
+		remaining, inc = limits.bTime, limits.bInc
+	}
+	if remaining <= 0 {
+		return 0, false
+	}
+
+	movesToGo := limits.movesToGo
+	if movesToGo <= 0 {
+		movesToGo = 30
+	}
+	budget := remaining/time.Duration(movesToGo) + inc*3/4
+	if budget > remaining-overhead {
+		budget = remaining - overhead
+	}
+	return maxDuration(budget, time.Millisecond), true
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (engine *uciEngine) startSearch(limits searchLimits) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	parent := context.Background()
+	if budget, ok := limits.timeBudget(engine.position.SideToMove(), engine.moveOverhead); ok {
+		ctx, cancel = context.WithTimeout(parent, budget)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+
+	done := make(chan struct{})
+	engine.cancel, engine.done = cancel, done
+	position := engine.position
+
+	go func() {
+		defer close(done)
+
+		s := search.NewSearch(engine.evaluator, engine.tt, func(info search.SearchInfo) {
+			engine.println(uciInfo(info))
+		})
+		info := s.Run(ctx, position, limits.depth)
+
+		// bestmove of an infinite search is only reported after stop
+		if limits.infinite {
+			<-ctx.Done()
+		}
+
+		if len(info.PV) > 0 {
+			engine.println("bestmove " + info.PV[0].UCI())
+		} else {
+			engine.println("bestmove 0000")
+		}
+	}()
+}
+
+// Stops the running search & waits for it to report its best move
+func (engine *uciEngine) stopSearch() {
+	if engine.cancel == nil {
+		return
+	}
+	engine.cancel()
+	<-engine.done
+	engine.cancel, engine.done = nil, nil
+}
+
+// info depth <d> score cp <x> | mate <y> nodes <n> nps <n> hashfull <permille> time <ms> pv <move1> ... <movei>
+func uciInfo(info search.SearchInfo) string {
+	score := fmt.Sprintf("cp %d", info.Score)
+	if info.Mate != 0 {
+		score = fmt.Sprintf("mate %d", info.Mate)
+	}
+
+	pv := make([]string, len(info.PV))
+	for i, move := range info.PV {
+		pv[i] = move.UCI()
+	}
+
+	return fmt.Sprintf("info depth %d score %s nodes %d nps %d hashfull %d time %d pv %s",
+		info.Depth, score, info.Nodes, info.NPS, info.Hashfull, info.Time.Milliseconds(), strings.Join(pv, " "))
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bhavya5jain/go-django-unchained/search"
+	"github.com/bhavya5jain/go-django-unchained/src"
+	"github.com/stretchr/testify/assert"
+)
+
+func runUCISession(t *testing.T, commands ...string) []string {
+	var out bytes.Buffer
+	err := runUCI(strings.NewReader(strings.Join(commands, "\n")+"\n"), &out)
+	assert.NoError(t, err)
+	return strings.Split(strings.TrimSpace(out.String()), "\n")
+}
+
+func TestUCI_Handshake(t *testing.T) {
+	lines := runUCISession(t, "uci", "isready", "quit")
+
+	assert.Contains(t, lines, "id name go-django-unchained")
+	assert.Contains(t, lines, "option name Move Overhead type spin default 10 min 0 max 5000")
+	assert.Contains(t, lines, "option name Hash type spin default 16 min 1 max 4096")
+	assert.Equal(t, []string{"uciok", "readyok"}, lines[len(lines)-2:])
+}
+
+func TestUCI_Go(t *testing.T) {
+	type UCITestCase struct {
+		desc             string
+		commands         []string
+		expectedBestMove string
+	}
+
+	tcs := []UCITestCase{
+		{
+			"startpos with moves",
+			[]string{"ucinewgame", "position startpos moves e2e4 e7e5 g1f3", "go depth 1"},
+			"",
+		},
+		{
+			"fen with moves",
+			[]string{"position fen r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1 moves e1g1", "go movetime 10"},
+			"",
+		},
+		{
+			"checkmated",
+			[]string{"position fen rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3", "go wtime 1000 btime 1000 winc 10 binc 10"},
+			"bestmove 0000",
+		},
+		{
+			"only legal move",
+			[]string{"position fen 7k/8/8/8/8/8/1r6/K1r5 w - - 0 1 moves", "go btime 1000 wtime 1000 movestogo 5"},
+			"bestmove a1b2",
+		},
+		{
+			"infinite search reports best move after stop",
+			[]string{"position startpos", "go infinite", "isready", "stop"},
+			"",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lines := runUCISession(t, append(tc.commands, "quit")...)
+			bestMove := lines[len(lines)-1]
+			assert.True(t, strings.HasPrefix(bestMove, "bestmove "), bestMove)
+			if tc.expectedBestMove != "" {
+				assert.Equal(t, tc.expectedBestMove, bestMove)
+			}
+		})
+	}
+}
+
+func TestUCI_Errors(t *testing.T) {
+	lines := runUCISession(t,
+		"position fen 8/8/8/8/8/8/8/8 w - - 0 1",
+		"position startpos moves e2e5",
+		"setoption name Hash value 0",
+		"go depth x",
+		"foo",
+		"quit",
+	)
+
+	assert.Len(t, lines, 5)
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, "info string "), line)
+	}
+}
+
+func TestUCI_SetOption(t *testing.T) {
+	engine := newUCIEngine(&bytes.Buffer{})
+
+	assert.NoError(t, engine.setOption(strings.Fields("name Move Overhead value 50")))
+	assert.Equal(t, 50*time.Millisecond, engine.moveOverhead)
+	assert.Error(t, engine.setOption(strings.Fields("name Move Overhead value -1")))
+	assert.Equal(t, 50*time.Millisecond, engine.moveOverhead)
+
+	assert.NoError(t, engine.setOption(strings.Fields("name Hash value 1")))
+	assert.Error(t, engine.setOption(strings.Fields("name Hash value 5000")))
+	assert.Error(t, engine.setOption(strings.Fields("name Hash value x")))
+}
+
+func TestSearchLimits_TimeBudget(t *testing.T) {
+	type TBTC struct {
+		desc           string
+		args           string
+		sideToMove     src.Color
+		expectedBudget time.Duration
+		expectedOk     bool
+	}
+
+	tcs := []TBTC{
+		{"movetime", "movetime 1000", src.White, 990 * time.Millisecond, true},
+		{"white clock", "wtime 30000 btime 60000 winc 1000 binc 0", src.White, 1750 * time.Millisecond, true},
+		{"black clock with moves to go", "wtime 30000 btime 60000 movestogo 10", src.Black, 6000 * time.Millisecond, true},
+		{"depth only", "depth 5", src.White, 0, false},
+		{"infinite", "infinite", src.White, 0, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			limits, err := parseSearchLimits(strings.Fields(tc.args))
+			assert.NoError(t, err)
+			budget, ok := limits.timeBudget(tc.sideToMove, 10*time.Millisecond)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expectedBudget, budget)
+		})
+	}
+}
+
+func TestUCIInfo(t *testing.T) {
+	position, _ := startingPositionFen.Parse()
+	e2e4, _ := position.ParseUCIMove("e2e4")
+	position.MakeMove(e2e4)
+	e7e5, _ := position.ParseUCIMove("e7e5")
+
+	assert.Equal(t,
+		"info depth 2 score cp 35 nodes 1200 nps 60000 hashfull 12 time 20 pv e2e4 e7e5",
+		uciInfo(search.SearchInfo{Depth: 2, Score: 35, Nodes: 1200, NPS: 60000, Hashfull: 12, Time: 20 * time.Millisecond, PV: []src.Move{e2e4, e7e5}}))
+	assert.Equal(t,
+		"info depth 3 score mate -1 nodes 40 nps 40000 hashfull 0 time 1 pv e2e4",
+		uciInfo(search.SearchInfo{Depth: 3, Mate: -1, Nodes: 40, NPS: 40000, Time: time.Millisecond, PV: []src.Move{e2e4}}))
+}